@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"reflect"
 
 	"github.com/iotaledger/hive.go/serializer"
 	"github.com/iotaledger/iota.go/v2/bech32"
@@ -47,6 +48,44 @@ type Address interface {
 	Bech32(hrp NetworkPrefix) string
 }
 
+// AddressesEqual tells whether the given addresses are equal, i.e. whether both are of the same type
+// and hold the same data.
+// Nil addresses, including typed nil pointers, only equal each other.
+func AddressesEqual(a Address, b Address) bool {
+	if aNil, bNil := isNilAddress(a), isNilAddress(b); aNil || bNil {
+		return aNil && bNil
+	}
+	aKey, err := addressKey(a)
+	if err != nil {
+		return false
+	}
+	bKey, err := addressKey(b)
+	if err != nil {
+		return false
+	}
+	return aKey == bKey
+}
+
+// returns the key under which the given address is deduplicated, made up of its serialized form. The serialized form
+// of the built-in address types already starts with their type, but the type is prefixed regardless, as a custom Address
+// implementation (i.e. one wrapping the serialization of another address type) might not denote its own type within it.
+func addressKey(addr Address) (string, error) {
+	data, err := addr.Serialize(serializer.DeSeriModeNoValidation)
+	if err != nil {
+		return "", fmt.Errorf("unable to serialize address %s of type %d: %w", addr, addr.Type(), err)
+	}
+	return string(append([]byte{addr.Type()}, data...)), nil
+}
+
+// tells whether the given address is nil or a typed nil pointer.
+func isNilAddress(addr Address) bool {
+	if addr == nil {
+		return true
+	}
+	v := reflect.ValueOf(addr)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
 // AddressSelector implements SerializableSelectorFunc for address types.
 func AddressSelector(addressType uint32) (serializer.Serializable, error) {
 	return newAddress(byte(addressType))
@@ -70,7 +109,9 @@ func bech32String(hrp NetworkPrefix, addr Address) string {
 	return s
 }
 
-// ParseBech32 decodes a bech32 encoded string.
+// ParseBech32 decodes a bech32 encoded string into the network prefix and the Address of the type denoted by its first byte.
+// Errors from decoding the string, i.e. bech32.ErrInvalidChecksum, are wrapped and an error wrapping ErrUnknownAddrType
+// is returned if the address type is not known.
 func ParseBech32(s string) (NetworkPrefix, Address, error) {
 	hrp, addrData, err := bech32.Decode(s)
 	if err != nil {
@@ -180,6 +221,13 @@ func AddressFromEd25519PubKey(pubKey ed25519.PublicKey) Ed25519Address {
 	return blake2b.Sum256(pubKey[:])
 }
 
+// Ed25519AddressFromPubKey returns a pointer to the address belonging to the given Ed25519 public key,
+// which is the BLAKE2b-256 hash of the public key. See AddressFromEd25519PubKey.
+func Ed25519AddressFromPubKey(pubKey ed25519.PublicKey) *Ed25519Address {
+	addr := AddressFromEd25519PubKey(pubKey)
+	return &addr
+}
+
 // selects the json object for the given type.
 func jsonAddressSelector(ty int) (JSONSerializable, error) {
 	var obj JSONSerializable
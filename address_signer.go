@@ -6,6 +6,7 @@ import (
 
 	"github.com/iotaledger/hive.go/serializer"
 	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/iotaledger/iota.go/v2/slip10"
 )
 
 var (
@@ -13,6 +14,8 @@ var (
 	ErrAddressKeysNotMapped = errors.New("key(s) for address not mapped")
 	// ErrAddressKeysWrongType gets returned if the specified keys to sign a message for a given address are of the wrong type.
 	ErrAddressKeysWrongType = errors.New("key(s) for address are of wrong type")
+	// ErrSigningDeclined gets returned if the signing of a message was declined, i.e. by the user of a wallet.
+	ErrSigningDeclined = errors.New("signing declined")
 )
 
 // AddressSigner produces signatures for messages which get verified against a given address.
@@ -43,6 +46,12 @@ func NewAddressKeysForEd25519Address(addr *Ed25519Address, prvKey ed25519.Privat
 
 // NewInMemoryAddressSigner creates a new InMemoryAddressSigner holding the given AddressKeys.
 func NewInMemoryAddressSigner(addrKeys ...AddressKeys) AddressSigner {
+	return NewInMemoryAddressSignerWithKeys(addrKeys...)
+}
+
+// NewInMemoryAddressSignerWithKeys creates a new InMemoryAddressSigner holding the given AddressKeys.
+// Unlike NewInMemoryAddressSigner it returns the concrete type, so that further keys can be added via AddKeyPair.
+func NewInMemoryAddressSignerWithKeys(addrKeys ...AddressKeys) *InMemoryAddressSigner {
 	ss := &InMemoryAddressSigner{
 		addrKeys: map[string]interface{}{},
 	}
@@ -57,6 +66,12 @@ type InMemoryAddressSigner struct {
 	addrKeys map[string]interface{}
 }
 
+// AddKeyPair maps the given private key to the given address so that the signer can produce signatures for it.
+func (s *InMemoryAddressSigner) AddKeyPair(addr Address, prvKey ed25519.PrivateKey) *InMemoryAddressSigner {
+	s.addrKeys[addr.String()] = prvKey
+	return s
+}
+
 func (s *InMemoryAddressSigner) Sign(addr Address, msg []byte) (signature serializer.Serializable, err error) {
 	switch addr.(type) {
 	case *Ed25519Address:
@@ -79,3 +94,89 @@ func (s *InMemoryAddressSigner) Sign(addr Address, msg []byte) (signature serial
 		return nil, fmt.Errorf("%w: type %T", ErrUnknownAddrType, addr)
 	}
 }
+
+// NewSeedAddressSigner creates a new SeedAddressSigner deriving its keys from the given seed.
+func NewSeedAddressSigner(seed []byte) *SeedAddressSigner {
+	return &SeedAddressSigner{
+		seed:      seed,
+		addrPaths: map[string]slip10.Path{},
+	}
+}
+
+// SeedAddressSigner implements AddressSigner by deriving the keys for addresses from a seed via SLIP-10.
+// Addresses must be registered through DeriveEd25519Address before messages can be signed for them.
+type SeedAddressSigner struct {
+	seed      []byte
+	addrPaths map[string]slip10.Path
+}
+
+// DeriveEd25519Address derives the Ed25519Address at the given SLIP-10 path, i.e. m/44'/4218'/0'/0'/0',
+// and registers it so that the signer can produce signatures for it.
+func (s *SeedAddressSigner) DeriveEd25519Address(path slip10.Path) (*Ed25519Address, error) {
+	key, err := slip10.DeriveKeyFromPath(s.seed, path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive key for path %s: %w", path, err)
+	}
+	addr := AddressFromEd25519PubKey(key.PrivateKey().Public().(ed25519.PublicKey))
+	s.addrPaths[addr.String()] = path
+	return &addr, nil
+}
+
+// Path returns the derivation path of the given address if it was registered.
+func (s *SeedAddressSigner) Path(addr Address) (slip10.Path, bool) {
+	path, has := s.addrPaths[addr.String()]
+	return path, has
+}
+
+func (s *SeedAddressSigner) Sign(addr Address, msg []byte) (signature serializer.Serializable, err error) {
+	switch addr.(type) {
+	case *Ed25519Address:
+		path, ok := s.addrPaths[addr.String()]
+		if !ok {
+			return nil, fmt.Errorf("can't sign message for Ed25519 address: %w", ErrAddressKeysNotMapped)
+		}
+
+		key, err := slip10.DeriveKeyFromPath(s.seed, path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to derive key for path %s: %w", path, err)
+		}
+		prvKey := key.PrivateKey()
+
+		ed25519Sig := &Ed25519Signature{}
+		copy(ed25519Sig.Signature[:], ed25519.Sign(prvKey, msg))
+		copy(ed25519Sig.PublicKey[:], prvKey.Public().(ed25519.PublicKey))
+
+		return ed25519Sig, nil
+	default:
+		return nil, fmt.Errorf("%w: type %T", ErrUnknownAddrType, addr)
+	}
+}
+
+// InteractiveAddressSignerConfirmFunc gets called to confirm the signing of the given message for the given address.
+type InteractiveAddressSignerConfirmFunc func(addr Address, msg []byte) (bool, error)
+
+// NewInteractiveAddressSigner creates a new InteractiveAddressSigner asking the given confirm function
+// before signing via the given inner AddressSigner.
+func NewInteractiveAddressSigner(confirm InteractiveAddressSignerConfirmFunc, inner AddressSigner) *InteractiveAddressSigner {
+	return &InteractiveAddressSigner{confirm: confirm, inner: inner}
+}
+
+// InteractiveAddressSigner implements AddressSigner by asking for a confirmation, i.e. of the user of a CLI wallet,
+// before every signature is produced by another AddressSigner. As the TransactionBuilder signs once per address,
+// the confirmation is asked once for every address of the inputs. If the signing is declined, an error
+// wrapping ErrSigningDeclined is returned, which aborts TransactionBuilder.Build.
+type InteractiveAddressSigner struct {
+	confirm InteractiveAddressSignerConfirmFunc
+	inner   AddressSigner
+}
+
+func (s *InteractiveAddressSigner) Sign(addr Address, msg []byte) (signature serializer.Serializable, err error) {
+	confirmed, err := s.confirm(addr, msg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to confirm signing for address %s: %w", addr, err)
+	}
+	if !confirmed {
+		return nil, fmt.Errorf("%w: for address %s", ErrSigningDeclined, addr)
+	}
+	return s.inner.Sign(addr, msg)
+}
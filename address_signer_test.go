@@ -0,0 +1,105 @@
+package iotago_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/iotaledger/iota.go/v2/slip10"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeedAddressSigner(t *testing.T) {
+	signer := iotago.NewSeedAddressSigner(tpkg.RandBytes(32))
+
+	path, err := slip10.ParsePath("m/44'/4218'/0'/0'/0'")
+	require.NoError(t, err)
+
+	addr, err := signer.DeriveEd25519Address(path)
+	require.NoError(t, err)
+
+	registeredPath, has := signer.Path(addr)
+	require.True(t, has)
+	require.Equal(t, path, registeredPath)
+
+	msg := []byte("message to sign")
+	sig, err := signer.Sign(addr, msg)
+	require.NoError(t, err)
+	require.NoError(t, sig.(*iotago.Ed25519Signature).Valid(msg, addr))
+
+	outputAddr, _ := tpkg.RandEd25519Address()
+	inputUTXO, _ := tpkg.RandUTXOInput()
+	_, err = iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: addr, Input: inputUTXO}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 50}).
+		Build(signer)
+	require.NoError(t, err)
+
+	// not registered address
+	_, err = signer.Sign(outputAddr, msg)
+	require.True(t, errors.Is(err, iotago.ErrAddressKeysNotMapped))
+}
+
+func TestInMemoryAddressSigner_AddKeyPair(t *testing.T) {
+	prvKey := tpkg.RandEd25519PrivateKey()
+	addr := iotago.AddressFromEd25519PubKey(prvKey.Public().(ed25519.PublicKey))
+
+	signer := iotago.NewInMemoryAddressSignerWithKeys()
+	msg := []byte("message to sign")
+	_, err := signer.Sign(&addr, msg)
+	require.True(t, errors.Is(err, iotago.ErrAddressKeysNotMapped))
+
+	sig, err := signer.AddKeyPair(&addr, prvKey).Sign(&addr, msg)
+	require.NoError(t, err)
+	require.NoError(t, sig.(*iotago.Ed25519Signature).Valid(msg, &addr))
+}
+
+func TestInteractiveAddressSigner(t *testing.T) {
+	prvKey := tpkg.RandEd25519PrivateKey()
+	addr := iotago.AddressFromEd25519PubKey(prvKey.Public().(ed25519.PublicKey))
+	inner := iotago.NewInMemoryAddressSigner(iotago.NewAddressKeysForEd25519Address(&addr, prvKey))
+
+	outputAddr, _ := tpkg.RandEd25519Address()
+	builder := iotago.NewTransactionBuilder()
+	for i := 0; i < 2; i++ {
+		inputUTXO, _ := tpkg.RandUTXOInput()
+		builder.AddInput(&iotago.ToBeSignedUTXOInput{Address: &addr, Input: inputUTXO})
+	}
+	builder.AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 50})
+
+	errConfirm := errors.New("terminal closed")
+
+	type test struct {
+		name      string
+		confirmed bool
+		err       error
+		buildErr  error
+	}
+	tests := []test{
+		{name: "ok - confirmed", confirmed: true},
+		{name: "err - declined", buildErr: iotago.ErrSigningDeclined},
+		{name: "err - confirmation failed", err: errConfirm, buildErr: errConfirm},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var confirms int
+			signer := iotago.NewInteractiveAddressSigner(func(confirmAddr iotago.Address, msg []byte) (bool, error) {
+				confirms++
+				require.True(t, iotago.AddressesEqual(confirmAddr, &addr))
+				require.NotEmpty(t, msg)
+				return tt.confirmed, tt.err
+			}, inner)
+
+			_, err := builder.Clone().Build(signer)
+			// the inputs share an address, so the signing is confirmed only once
+			require.Equal(t, 1, confirms)
+			if tt.buildErr != nil {
+				require.True(t, errors.Is(err, tt.buildErr))
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -3,11 +3,14 @@ package iotago_test
 import (
 	"errors"
 	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/iota.go/v2/bech32"
+	"github.com/iotaledger/iota.go/v2/ed25519"
 	"github.com/iotaledger/iota.go/v2/tpkg"
 	"testing"
 
 	"github.com/iotaledger/iota.go/v2"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
 )
 
 func TestEd25519Address_Deserialize(t *testing.T) {
@@ -107,3 +110,45 @@ func TestParseBech32(t *testing.T) {
 		})
 	}
 }
+
+func TestParseBech32_Errors(t *testing.T) {
+	valid := bech32Tests[0].bech32
+
+	// alter the last checksum character
+	invalidChecksum := valid[:len(valid)-1] + "q"
+	_, _, err := iotago.ParseBech32(invalidChecksum)
+	assert.True(t, errors.Is(err, bech32.ErrInvalidChecksum))
+	assert.False(t, errors.Is(err, iotago.ErrUnknownAddrType))
+
+	unknownType, err := bech32.Encode(string(iotago.PrefixMainnet), append([]byte{0xff}, tpkg.RandBytes(iotago.Ed25519AddressBytesLength)...))
+	assert.NoError(t, err)
+	_, _, err = iotago.ParseBech32(unknownType)
+	assert.True(t, errors.Is(err, iotago.ErrUnknownAddrType))
+	assert.False(t, errors.Is(err, bech32.ErrInvalidChecksum))
+}
+
+func TestEd25519AddressFromPubKey(t *testing.T) {
+	pubKey := tpkg.RandEd25519PrivateKey().Public().(ed25519.PublicKey)
+
+	addr := iotago.Ed25519AddressFromPubKey(pubKey)
+	assert.Equal(t, iotago.Ed25519Address(blake2b.Sum256(pubKey)), *addr)
+	assert.Equal(t, iotago.AddressFromEd25519PubKey(pubKey), *addr)
+}
+
+func TestAddressesEqual(t *testing.T) {
+	addr, _ := tpkg.RandEd25519Address()
+	same := *addr
+	other, _ := tpkg.RandEd25519Address()
+
+	assert.True(t, iotago.AddressesEqual(addr, &same))
+	assert.False(t, iotago.AddressesEqual(addr, other))
+	assert.False(t, iotago.AddressesEqual(addr, &unknownAddress{Ed25519Address: *addr}))
+	assert.False(t, iotago.AddressesEqual(addr, nil))
+	assert.True(t, iotago.AddressesEqual(nil, nil))
+
+	var typedNil *iotago.Ed25519Address
+	assert.False(t, iotago.AddressesEqual(addr, typedNil))
+	assert.False(t, iotago.AddressesEqual(typedNil, addr))
+	assert.True(t, iotago.AddressesEqual(typedNil, nil))
+	assert.True(t, iotago.AddressesEqual(typedNil, typedNil))
+}
@@ -0,0 +1,262 @@
+package iotago
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+)
+
+const (
+	cborMajorUnsignedInt = 0
+	cborMajorNegativeInt = 1
+	cborMajorTextString  = 3
+	cborMajorArray       = 4
+	cborMajorMap         = 5
+	cborMajorSimple      = 7
+
+	cborFalse   = 0xf4
+	cborTrue    = 0xf5
+	cborNull    = 0xf6
+	cborFloat64 = 0xfb
+
+	// the maximum nesting depth of arrays and maps accepted when decoding CBOR.
+	cborMaxDepth = 64
+)
+
+var (
+	// ErrInvalidCBOR gets returned when CBOR data is malformed or uses features outside of the JSON data model.
+	ErrInvalidCBOR = errors.New("invalid CBOR")
+)
+
+// jsonToCBOR encodes the given JSON as CBOR. Objects become maps with text string keys in deterministic order,
+// integral numbers become (negative) integers and all other numbers become float64 values.
+// The encoding only covers the JSON data model and is not canonical CBOR as defined by RFC 8949 section 4.2:
+// floats are not reduced to their shortest form and integers outside of the 64-bit range are encoded as floats.
+func jsonToCBOR(jsonData []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(jsonData))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := writeCBORValue(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// cborToJSON decodes the given CBOR, as produced by jsonToCBOR, back into JSON.
+func cborToJSON(cborData []byte) ([]byte, error) {
+	r := bytes.NewReader(cborData)
+	v, err := readCBORValue(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes", ErrInvalidCBOR, r.Len())
+	}
+	return json.Marshal(v)
+}
+
+func writeCBORHead(buf *bytes.Buffer, major byte, n uint64) {
+	major <<= 5
+	switch {
+	case n < 24:
+		buf.WriteByte(major | byte(n))
+	case n <= math.MaxUint8:
+		buf.WriteByte(major | 24)
+		buf.WriteByte(byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(major | 25)
+		_ = binary.Write(buf, binary.BigEndian, uint16(n))
+	case n <= math.MaxUint32:
+		buf.WriteByte(major | 26)
+		_ = binary.Write(buf, binary.BigEndian, uint32(n))
+	default:
+		buf.WriteByte(major | 27)
+		_ = binary.Write(buf, binary.BigEndian, n)
+	}
+}
+
+func writeCBORValue(buf *bytes.Buffer, v interface{}) error {
+	switch x := v.(type) {
+	case nil:
+		buf.WriteByte(cborNull)
+	case bool:
+		if x {
+			buf.WriteByte(cborTrue)
+			return nil
+		}
+		buf.WriteByte(cborFalse)
+	case string:
+		writeCBORHead(buf, cborMajorTextString, uint64(len(x)))
+		buf.WriteString(x)
+	case json.Number:
+		if n, err := strconv.ParseUint(x.String(), 10, 64); err == nil {
+			writeCBORHead(buf, cborMajorUnsignedInt, n)
+			return nil
+		}
+		if n, err := strconv.ParseInt(x.String(), 10, 64); err == nil && n < 0 {
+			writeCBORHead(buf, cborMajorNegativeInt, uint64(-(n + 1)))
+			return nil
+		}
+		f, err := x.Float64()
+		if err != nil {
+			return fmt.Errorf("unable to encode number %s as CBOR: %w", x, err)
+		}
+		buf.WriteByte(cborFloat64)
+		_ = binary.Write(buf, binary.BigEndian, math.Float64bits(f))
+	case []interface{}:
+		writeCBORHead(buf, cborMajorArray, uint64(len(x)))
+		for _, elem := range x {
+			if err := writeCBORValue(buf, elem); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		// deterministic order: shorter keys first, then lexical, which equals the order of the encoded keys
+		sort.Slice(keys, func(i, j int) bool {
+			if len(keys[i]) != len(keys[j]) {
+				return len(keys[i]) < len(keys[j])
+			}
+			return keys[i] < keys[j]
+		})
+		writeCBORHead(buf, cborMajorMap, uint64(len(x)))
+		for _, k := range keys {
+			writeCBORHead(buf, cborMajorTextString, uint64(len(k)))
+			buf.WriteString(k)
+			if err := writeCBORValue(buf, x[k]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unable to encode %T as CBOR", v)
+	}
+	return nil
+}
+
+func readCBORHead(r *bytes.Reader) (byte, byte, uint64, error) {
+	initial, err := r.ReadByte()
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("%w: %s", ErrInvalidCBOR, err)
+	}
+	major, info := initial>>5, initial&0x1f
+
+	var size int
+	switch {
+	case info < 24 || major == cborMajorSimple:
+		return major, info, uint64(info), nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	default:
+		return 0, 0, 0, fmt.Errorf("%w: unsupported additional information %d", ErrInvalidCBOR, info)
+	}
+
+	if r.Len() < size {
+		return 0, 0, 0, fmt.Errorf("%w: not enough data for argument", ErrInvalidCBOR)
+	}
+	var n uint64
+	for i := 0; i < size; i++ {
+		b, _ := r.ReadByte()
+		n = n<<8 | uint64(b)
+	}
+	return major, info, n, nil
+}
+
+func readCBORValue(r *bytes.Reader, depth int) (interface{}, error) {
+	if depth > cborMaxDepth {
+		return nil, fmt.Errorf("%w: exceeds max nesting depth of %d", ErrInvalidCBOR, cborMaxDepth)
+	}
+
+	major, info, n, err := readCBORHead(r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case cborMajorUnsignedInt:
+		return json.Number(strconv.FormatUint(n, 10)), nil
+	case cborMajorNegativeInt:
+		neg := new(big.Int).SetUint64(n)
+		neg.Add(neg, big.NewInt(1)).Neg(neg)
+		return json.Number(neg.String()), nil
+	case cborMajorTextString:
+		if n > uint64(r.Len()) {
+			return nil, fmt.Errorf("%w: text string exceeds data", ErrInvalidCBOR)
+		}
+		str := make([]byte, n)
+		_, _ = r.Read(str)
+		return string(str), nil
+	case cborMajorArray:
+		// every element occupies at least one byte
+		if n > uint64(r.Len()) {
+			return nil, fmt.Errorf("%w: array exceeds data", ErrInvalidCBOR)
+		}
+		arr := make([]interface{}, n)
+		for i := range arr {
+			if arr[i], err = readCBORValue(r, depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return arr, nil
+	case cborMajorMap:
+		if n > uint64(r.Len()) {
+			return nil, fmt.Errorf("%w: map exceeds data", ErrInvalidCBOR)
+		}
+		m := make(map[string]interface{}, n)
+		for i := uint64(0); i < n; i++ {
+			k, err := readCBORValue(r, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: map key must be a text string", ErrInvalidCBOR)
+			}
+			if m[key], err = readCBORValue(r, depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	case cborMajorSimple:
+		switch 0xe0 | info {
+		case cborFalse:
+			return false, nil
+		case cborTrue:
+			return true, nil
+		case cborNull:
+			return nil, nil
+		case cborFloat64:
+			if r.Len() < 8 {
+				return nil, fmt.Errorf("%w: not enough data for float64", ErrInvalidCBOR)
+			}
+			var bits uint64
+			_ = binary.Read(r, binary.BigEndian, &bits)
+			f := math.Float64frombits(bits)
+			if math.IsNaN(f) || math.IsInf(f, 0) {
+				return nil, fmt.Errorf("%w: non finite float", ErrInvalidCBOR)
+			}
+			return json.Number(strconv.FormatFloat(f, 'g', -1, 64)), nil
+		}
+		return nil, fmt.Errorf("%w: unsupported simple value %d", ErrInvalidCBOR, info)
+	}
+	return nil, fmt.Errorf("%w: unsupported major type %d", ErrInvalidCBOR, major)
+}
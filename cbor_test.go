@@ -0,0 +1,98 @@
+package iotago
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// test vectors from RFC 8949 appendix A which are within the JSON data model.
+var cborRFC8949Vectors = []struct {
+	json string
+	cbor string
+}{
+	{`0`, "00"},
+	{`1`, "01"},
+	{`10`, "0a"},
+	{`23`, "17"},
+	{`24`, "1818"},
+	{`25`, "1819"},
+	{`100`, "1864"},
+	{`1000`, "1903e8"},
+	{`1000000`, "1a000f4240"},
+	{`1000000000000`, "1b000000e8d4a51000"},
+	{`18446744073709551615`, "1bffffffffffffffff"},
+	{`-1`, "20"},
+	{`-10`, "29"},
+	{`-100`, "3863"},
+	{`-1000`, "3903e7"},
+	{`1.1`, "fb3ff199999999999a"},
+	{`-4.1`, "fbc010666666666666"},
+	{`1e+300`, "fb7e37e43c8800759c"},
+	{`false`, "f4"},
+	{`true`, "f5"},
+	{`null`, "f6"},
+	{`""`, "60"},
+	{`"a"`, "6161"},
+	{`"IETF"`, "6449455446"},
+	{`"\"\\"`, "62225c"},
+	{`"ü"`, "62c3bc"},
+	{`"水"`, "63e6b0b4"},
+	{`[]`, "80"},
+	{`[1,2,3]`, "83010203"},
+	{`[1,[2,3],[4,5]]`, "8301820203820405"},
+	{`[1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17,18,19,20,21,22,23,24,25]`, "98190102030405060708090a0b0c0d0e0f101112131415161718181819"},
+	{`{}`, "a0"},
+	{`{"a":1,"b":[2,3]}`, "a26161016162820203"},
+	{`["a",{"b":"c"}]`, "826161a161626163"},
+	{`{"a":"A","b":"B","c":"C","d":"D","e":"E"}`, "a56161614161626142616361436164614461656145"},
+}
+
+func TestJSONToCBOR_RFC8949Vectors(t *testing.T) {
+	for _, v := range cborRFC8949Vectors {
+		t.Run(v.json, func(t *testing.T) {
+			cborData, err := jsonToCBOR([]byte(v.json))
+			require.NoError(t, err)
+			assert.Equal(t, v.cbor, hex.EncodeToString(cborData))
+		})
+	}
+}
+
+func TestCBORToJSON_RFC8949Vectors(t *testing.T) {
+	for _, v := range cborRFC8949Vectors {
+		t.Run(v.cbor, func(t *testing.T) {
+			cborData, err := hex.DecodeString(v.cbor)
+			require.NoError(t, err)
+			jsonData, err := cborToJSON(cborData)
+			require.NoError(t, err)
+			assert.JSONEq(t, v.json, string(jsonData))
+		})
+	}
+}
+
+func TestCBORToJSON_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		cbor string
+	}{
+		// byte strings, half floats and indefinite lengths are outside of the supported subset
+		{"byte string", "4401020304"},
+		{"half float", "f93e00"},
+		{"indefinite length array", "9f018202039f0405ffff"},
+		{"non text map key", "a10102"},
+		{"infinity", "fb7ff0000000000000"},
+		{"truncated", "1903"},
+		{"trailing bytes", "0000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cborData, err := hex.DecodeString(tt.cbor)
+			require.NoError(t, err)
+			_, err = cborToJSON(cborData)
+			assert.True(t, errors.Is(err, ErrInvalidCBOR))
+		})
+	}
+}
@@ -1,11 +1,16 @@
 package iotago_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 
+	"github.com/iotaledger/iota.go/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDynamicJSONArrayDeserialization(t *testing.T) {
@@ -47,3 +52,48 @@ func TestDynamicJSONArrayDeserialization(t *testing.T) {
 	}
 
 }
+
+// the JSON fixtures hold objects in the representation of the node's REST API, including its "type" discriminators,
+// field names and field order, and are expected to round trip byte for byte.
+func TestNodeAPIJSONFixtures(t *testing.T) {
+	fixtureData, err := ioutil.ReadFile(filepath.Join("testdata", t.Name()+".json"))
+	require.NoError(t, err)
+
+	var fixtures []struct {
+		Object string          `json:"object"`
+		JSON   json.RawMessage `json:"json"`
+	}
+	require.NoError(t, json.Unmarshal(fixtureData, &fixtures))
+
+	objects := map[string]func() json.Marshaler{
+		"UTXOInput":                    func() json.Marshaler { return &iotago.UTXOInput{} },
+		"TreasuryInput":                func() json.Marshaler { return &iotago.TreasuryInput{} },
+		"SigLockedSingleOutput":        func() json.Marshaler { return &iotago.SigLockedSingleOutput{} },
+		"SigLockedDustAllowanceOutput": func() json.Marshaler { return &iotago.SigLockedDustAllowanceOutput{} },
+		"TreasuryOutput":               func() json.Marshaler { return &iotago.TreasuryOutput{} },
+		"Ed25519Signature":             func() json.Marshaler { return &iotago.Ed25519Signature{} },
+		"SignatureUnlockBlock":         func() json.Marshaler { return &iotago.SignatureUnlockBlock{} },
+		"ReferenceUnlockBlock":         func() json.Marshaler { return &iotago.ReferenceUnlockBlock{} },
+		"Transaction":                  func() json.Marshaler { return &iotago.Transaction{} },
+		"Indexation":                   func() json.Marshaler { return &iotago.Indexation{} },
+		"Milestone":                    func() json.Marshaler { return &iotago.Milestone{} },
+		"Receipt":                      func() json.Marshaler { return &iotago.Receipt{} },
+		"TreasuryTransaction":          func() json.Marshaler { return &iotago.TreasuryTransaction{} },
+	}
+
+	for i, fixture := range fixtures {
+		t.Run(fmt.Sprintf("%d %s", i, fixture.Object), func(t *testing.T) {
+			newObj, has := objects[fixture.Object]
+			require.True(t, has, "unknown fixture object %s", fixture.Object)
+
+			var expected bytes.Buffer
+			require.NoError(t, json.Compact(&expected, fixture.JSON))
+
+			obj := newObj()
+			require.NoError(t, json.Unmarshal(fixture.JSON, obj))
+			jsonData, err := obj.MarshalJSON()
+			require.NoError(t, err)
+			require.Equal(t, expected.String(), string(jsonData))
+		})
+	}
+}
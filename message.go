@@ -126,7 +126,14 @@ func (m *Message) MustID() MessageID {
 }
 
 // POW computes the PoW score of the Message.
+//
+// Deprecated: use PoWScore instead.
 func (m *Message) POW() (float64, error) {
+	return m.PoWScore()
+}
+
+// PoWScore computes the PoW score of the Message from its serialized bytes including its nonce.
+func (m *Message) PoWScore() (float64, error) {
 	data, err := m.Serialize(serializer.DeSeriModeNoValidation)
 	if err != nil {
 		return 0, fmt.Errorf("can't compute message PoW score: %w", err)
@@ -175,7 +182,32 @@ func (m *Message) Deserialize(data []byte, deSeriMode serializer.DeSerialization
 }
 
 func (m *Message) Serialize(deSeriMode serializer.DeSerializationMode) ([]byte, error) {
-	data, err := serializer.NewSerializer().
+	data, err := m.serialize(deSeriMode)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MessageBinSerializedMaxSize {
+		return nil, fmt.Errorf("%w: size %d bytes", ErrMessageExceedsMaxSize, len(data))
+	}
+	return data, nil
+}
+
+// ValidateSize checks whether the serialized Message is at most maxBytes big.
+// Returns an error wrapping ErrMessageExceedsMaxSize containing the actual size and the limit otherwise.
+func (m *Message) ValidateSize(maxBytes int) error {
+	data, err := m.serialize(serializer.DeSeriModeNoValidation)
+	if err != nil {
+		return err
+	}
+	if len(data) > maxBytes {
+		return fmt.Errorf("%w: message has a size of %d bytes but the max is %d bytes", ErrMessageExceedsMaxSize, len(data), maxBytes)
+	}
+	return nil
+}
+
+// serializes the Message without checking its size against MessageBinSerializedMaxSize.
+func (m *Message) serialize(deSeriMode serializer.DeSerializationMode) ([]byte, error) {
+	return serializer.NewSerializer().
 		Do(func() {
 			if deSeriMode.HasMode(serializer.DeSeriModePerformLexicalOrdering) {
 				m.Parents = serializer.RemoveDupsAndSortByLexicalOrderArrayOf32Bytes(m.Parents)
@@ -194,13 +226,6 @@ func (m *Message) Serialize(deSeriMode serializer.DeSerializationMode) ([]byte,
 			return fmt.Errorf("unable to serialize message nonce: %w", err)
 		}).
 		Serialize()
-	if err != nil {
-		return nil, err
-	}
-	if len(data) > MessageBinSerializedMaxSize {
-		return nil, fmt.Errorf("%w: size %d bytes", ErrMessageExceedsMaxSize, len(data))
-	}
-	return data, nil
 }
 
 func (m *Message) MarshalJSON() ([]byte, error) {
@@ -235,6 +260,25 @@ func (m *Message) UnmarshalJSON(bytes []byte) error {
 	return nil
 }
 
+// MarshalCBOR encodes the Message as CBOR mirroring the structure of its JSON encoding, hence binary fields are
+// hex encoded text strings. The encoding is deterministic but not canonical CBOR, see jsonToCBOR.
+func (m *Message) MarshalCBOR() ([]byte, error) {
+	jsonData, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return jsonToCBOR(jsonData)
+}
+
+// UnmarshalCBOR decodes the Message from CBOR produced by MarshalCBOR.
+func (m *Message) UnmarshalCBOR(data []byte) error {
+	jsonData, err := cborToJSON(data)
+	if err != nil {
+		return err
+	}
+	return m.UnmarshalJSON(jsonData)
+}
+
 // selects the json object for the given type.
 func jsonPayloadSelector(ty int) (JSONSerializable, error) {
 	var obj JSONSerializable
@@ -2,12 +2,23 @@ package iotago
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/iotaledger/hive.go/serializer"
 	"github.com/iotaledger/iota.go/v2/pow"
 )
 
+var (
+	// ErrRemotePoWUnavailable gets returned if a node is asked to do the proof-of-work but it does not support it.
+	ErrRemotePoWUnavailable = errors.New("remote proof-of-work unavailable")
+	// ErrMessageNonceNotSet gets returned if the ID of a message is requested before its nonce was set.
+	ErrMessageNonceNotSet = errors.New("message nonce not set")
+)
+
+// NodeFeaturePoW is the feature a node exposes via its info if it does the proof-of-work for submitted messages.
+const NodeFeaturePoW = "PoW"
+
 // NewMessageBuilder creates a new MessageBuilder.
 func NewMessageBuilder() *MessageBuilder {
 	return &MessageBuilder{
@@ -19,16 +30,97 @@ func NewMessageBuilder() *MessageBuilder {
 type MessageBuilder struct {
 	msg *Message
 	err error
+	// the tip selection to perform on Build.
+	tipSelection func() error
+	// the local proof-of-work to perform on Build.
+	localPoW func() error
+	// the remote proof-of-work to perform on Build.
+	remotePoW func() (*Message, error)
+	// the minimum PoW score the built message must meet, zero if not checked.
+	minPoWScore float64
 }
 
 // Build builds the Message or returns any error which occurred during the build steps.
+// If a TipSelector was configured via WithTipSelector, the parents are selected first.
+// An error wrapping ErrMessageExceedsMaxSize is returned if the Message exceeds MessageBinSerializedMaxSize.
+// If a proof-of-work was configured via WithRemotePoW or WithLocalPoW, it is performed before the Message is returned.
+// If a minimum PoW score was set via WithMinPoWScore, an error wrapping ErrMessagePoWScoreTooLow is returned
+// if the Message does not meet it.
 func (mb *MessageBuilder) Build() (*Message, error) {
+	msg, err := mb.build()
+	if err != nil {
+		return nil, err
+	}
+	if err := mb.checkPoWScore(msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// builds the Message by performing the configured tip selection and proof-of-work.
+func (mb *MessageBuilder) build() (*Message, error) {
 	if mb.err != nil {
 		return nil, mb.err
 	}
+	if mb.tipSelection != nil {
+		if err := mb.tipSelection(); err != nil {
+			return nil, err
+		}
+	}
+	// the nonce has a fixed size, so an oversized message can be rejected before doing the proof-of-work
+	if err := mb.msg.ValidateSize(MessageBinSerializedMaxSize); err != nil {
+		return nil, err
+	}
+	if mb.remotePoW != nil {
+		msg, err := mb.remotePoW()
+		switch {
+		case err == nil:
+			return msg, nil
+		case !errors.Is(err, ErrRemotePoWUnavailable) || mb.localPoW == nil:
+			return nil, err
+		}
+	}
+	if mb.localPoW != nil {
+		if err := mb.localPoW(); err != nil {
+			return nil, err
+		}
+	}
 	return mb.msg, nil
 }
 
+// checks that the given Message meets the minimum PoW score set via WithMinPoWScore.
+func (mb *MessageBuilder) checkPoWScore(msg *Message) error {
+	if mb.minPoWScore == 0 {
+		return nil
+	}
+	score, err := msg.PoWScore()
+	if err != nil {
+		return err
+	}
+	if score < mb.minPoWScore {
+		return fmt.Errorf("%w: achieved a score of %.2f but a score of at least %.2f is required", ErrMessagePoWScoreTooLow, score, mb.minPoWScore)
+	}
+	return nil
+}
+
+// BuildAndID works like Build but additionally returns the ID of the built Message.
+// As the ID covers the nonce, an error wrapping ErrMessageNonceNotSet is returned if the
+// nonce was neither computed via a proof-of-work nor set via WithNonce.
+func (mb *MessageBuilder) BuildAndID() (*Message, *MessageID, error) {
+	msg, err := mb.Build()
+	if err != nil {
+		return nil, nil, err
+	}
+	if msg.Nonce == 0 {
+		return nil, nil, fmt.Errorf("%w: can't compute message ID", ErrMessageNonceNotSet)
+	}
+	msgID, err := msg.ID()
+	if err != nil {
+		return nil, nil, err
+	}
+	return msg, msgID, nil
+}
+
 // NetworkID sets the network ID for which this message is meant for.
 func (mb *MessageBuilder) NetworkID(networkID uint64) *MessageBuilder {
 	if mb.err != nil {
@@ -88,6 +180,24 @@ func (mb *MessageBuilder) Tips(ctx context.Context, nodeAPI *NodeHTTPAPIClient)
 	return mb
 }
 
+// WithTipSelector defers the selection of the parents to Build, where the given TipSelector is used to select them,
+// so that the parents are as recent as possible when the proof-of-work is done. Parents set via Parents,
+// ParentsMessageIDs or Tips are overwritten. The selection can be cancelled by cancelling the given context.
+func (mb *MessageBuilder) WithTipSelector(ctx context.Context, tipSelector TipSelector) *MessageBuilder {
+	if mb.err != nil {
+		return mb
+	}
+	mb.tipSelection = func() error {
+		parents, err := tipSelector.SelectTips(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to select tips: %w", err)
+		}
+		mb.msg.Parents = serializer.RemoveDupsAndSortByLexicalOrderArrayOf32Bytes(parents)
+		return nil
+	}
+	return mb
+}
+
 // Parents sets the parents of the message.
 func (mb *MessageBuilder) Parents(parents [][]byte) *MessageBuilder {
 	if mb.err != nil {
@@ -114,6 +224,29 @@ func (mb *MessageBuilder) ParentsMessageIDs(parents MessageIDs) *MessageBuilder
 	return mb
 }
 
+// WithNonce sets the given nonce on the message and skips any proof-of-work configured via
+// WithLocalPoW or WithRemotePoW. This is useful to build messages with stable bytes and IDs, i.e. in tests.
+func (mb *MessageBuilder) WithNonce(nonce uint64) *MessageBuilder {
+	if mb.err != nil {
+		return mb
+	}
+	mb.msg.Nonce = nonce
+	mb.localPoW = nil
+	mb.remotePoW = nil
+	return mb
+}
+
+// WithMinPoWScore lets Build assert that the built message meets the given minimum PoW score, i.e. the
+// NetworkParameters.MinPoWScore of the network the message is submitted to, so that a message which the
+// node would reject is not submitted in the first place. A minimum of zero disables the check, which is the default.
+func (mb *MessageBuilder) WithMinPoWScore(minPoWScore float64) *MessageBuilder {
+	if mb.err != nil {
+		return mb
+	}
+	mb.minPoWScore = minPoWScore
+	return mb
+}
+
 // ProofOfWork does the proof-of-work needed in order to satisfy the given target score.
 // It can be cancelled by cancelling the given context. This function should appear
 // as the last step before Build.
@@ -121,10 +254,75 @@ func (mb *MessageBuilder) ProofOfWork(ctx context.Context, targetScore float64,
 	if mb.err != nil {
 		return mb
 	}
+	mb.err = mb.doProofOfWork(ctx, targetScore, numWorkers...)
+	return mb
+}
+
+// WithLocalPoW defers the proof-of-work needed in order to satisfy the given target score to Build,
+// so that it is computed over the final message regardless of the order of the other build steps.
+// numWorkers defines the amount of goroutines used to perform the proof-of-work and the computation
+// can be cancelled by cancelling the given context.
+func (mb *MessageBuilder) WithLocalPoW(ctx context.Context, targetScore float64, numWorkers int) *MessageBuilder {
+	if mb.err != nil {
+		return mb
+	}
+	mb.localPoW = func() error {
+		return mb.doProofOfWork(ctx, targetScore, numWorkers)
+	}
+	return mb
+}
+
+// MessageBuilderPoWFunc configures the proof-of-work on the given MessageBuilder so that the built message
+// satisfies the given target score, i.e. via WithLocalPoW or WithNonce.
+type MessageBuilderPoWFunc func(mb *MessageBuilder, targetScore float64) *MessageBuilder
+
+// LocalPoW returns a MessageBuilderPoWFunc which defers the proof-of-work to Build via WithLocalPoW
+// using the given amount of workers.
+func LocalPoW(ctx context.Context, numWorkers int) MessageBuilderPoWFunc {
+	return func(mb *MessageBuilder, targetScore float64) *MessageBuilder {
+		return mb.WithLocalPoW(ctx, targetScore, numWorkers)
+	}
+}
+
+// WithRemotePoW instructs the builder to let the given node do the proof-of-work: on Build, the message is
+// submitted without a nonce and the message as stored by the node is returned. If the node does not expose
+// the PoW feature, Build falls back to the proof-of-work configured via WithLocalPoW or returns an error
+// wrapping ErrRemotePoWUnavailable if none is set.
+func (mb *MessageBuilder) WithRemotePoW(ctx context.Context, nodeAPI *NodeHTTPAPIClient) *MessageBuilder {
+	if mb.err != nil {
+		return mb
+	}
+	mb.remotePoW = func() (*Message, error) {
+		info, err := nodeAPI.Info(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to query node info for remote proof-of-work: %w", err)
+		}
+
+		var supportsPoW bool
+		for _, feature := range info.Features {
+			if feature == NodeFeaturePoW {
+				supportsPoW = true
+				break
+			}
+		}
+		if !supportsPoW {
+			return nil, fmt.Errorf("%w: node %s does not expose the %s feature", ErrRemotePoWUnavailable, nodeAPI.BaseURL, NodeFeaturePoW)
+		}
+
+		mb.msg.Nonce = 0
+		msg, err := nodeAPI.SubmitMessage(ctx, mb.msg)
+		if err != nil {
+			return nil, fmt.Errorf("unable to submit message for remote proof-of-work: %w", err)
+		}
+		return msg, nil
+	}
+	return mb
+}
+
+func (mb *MessageBuilder) doProofOfWork(ctx context.Context, targetScore float64, numWorkers ...int) error {
 	msgData, err := mb.msg.Serialize(serializer.DeSeriModePerformValidation)
 	if err != nil {
-		mb.err = err
-		return mb
+		return err
 	}
 
 	// cut out the nonce
@@ -132,9 +330,8 @@ func (mb *MessageBuilder) ProofOfWork(ctx context.Context, targetScore float64,
 	worker := pow.New(numWorkers...)
 	nonce, err := worker.Mine(ctx, powRelevantData, targetScore)
 	if err != nil {
-		mb.err = fmt.Errorf("unable to complete proof-of-work: %w", err)
-		return mb
+		return fmt.Errorf("unable to complete proof-of-work: %w", err)
 	}
 	mb.msg.Nonce = nonce
-	return mb
+	return nil
 }
@@ -1,12 +1,19 @@
 package iotago_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/iota.go/v2/pow"
 	"github.com/iotaledger/iota.go/v2/tpkg"
 	"testing"
 
 	"github.com/iotaledger/iota.go/v2"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
 )
 
 func TestMessageBuilder(t *testing.T) {
@@ -29,3 +36,194 @@ func TestMessageBuilder(t *testing.T) {
 	require.NoError(t, err)
 	require.GreaterOrEqual(t, powScore, targetPoWScore)
 }
+
+func TestMessageBuilder_WithLocalPoW(t *testing.T) {
+	const targetPoWScore float64 = 500
+
+	// the PoW is done on Build, therefore the parents can be set afterwards
+	msg, err := iotago.NewMessageBuilder().
+		WithLocalPoW(context.Background(), targetPoWScore, 2).
+		Payload(&iotago.Indexation{Index: []byte("hello world"), Data: []byte{1, 2, 3, 4}}).
+		ParentsMessageIDs(tpkg.SortedRand32BytArray(4)).
+		Build()
+	require.NoError(t, err)
+
+	powScore, err := msg.POW()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, powScore, targetPoWScore)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = iotago.NewMessageBuilder().
+		ParentsMessageIDs(tpkg.SortedRand32BytArray(4)).
+		WithLocalPoW(ctx, 1<<20, 1).
+		Build()
+	require.True(t, errors.Is(err, pow.ErrCancelled))
+}
+
+func TestMessageBuilder_WithRemotePoW(t *testing.T) {
+	defer gock.Off()
+
+	const targetPoWScore float64 = 500
+
+	mockInfo := func(features ...string) {
+		gock.New(nodeAPIUrl).
+			Get(iotago.NodeAPIRouteInfo).
+			Reply(200).
+			JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.NodeInfoResponse{Features: features}})
+	}
+
+	// node does the PoW
+	storedMsg := &iotago.Message{Parents: tpkg.SortedRand32BytArray(2), Nonce: 1337}
+	storedMsgID, err := storedMsg.ID()
+	require.NoError(t, err)
+	storedMsgIDHex := hex.EncodeToString(storedMsgID[:])
+	serializedStoredMsg, err := storedMsg.Serialize(serializer.DeSeriModeNoValidation)
+	require.NoError(t, err)
+
+	mockInfo(iotago.NodeFeaturePoW)
+	gock.New(nodeAPIUrl).
+		Post(iotago.NodeAPIRouteMessages).
+		Reply(200).
+		AddHeader("Location", storedMsgIDHex)
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteMessageBytes, storedMsgIDHex)).
+		Reply(200).
+		Body(bytes.NewReader(serializedStoredMsg))
+
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+	msg, err := iotago.NewMessageBuilder().
+		WithRemotePoW(context.Background(), nodeAPI).
+		WithLocalPoW(context.Background(), targetPoWScore, 1).
+		Build()
+	require.NoError(t, err)
+	require.EqualValues(t, storedMsg, msg)
+	require.True(t, gock.IsDone())
+
+	// node without PoW feature falls back to the local PoW
+	mockInfo()
+	msg, err = iotago.NewMessageBuilder().
+		ParentsMessageIDs(tpkg.SortedRand32BytArray(2)).
+		WithRemotePoW(context.Background(), nodeAPI).
+		WithLocalPoW(context.Background(), targetPoWScore, 1).
+		Build()
+	require.NoError(t, err)
+	powScore, err := msg.POW()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, powScore, targetPoWScore)
+
+	// and errors without a local PoW to fall back to
+	mockInfo()
+	_, err = iotago.NewMessageBuilder().
+		ParentsMessageIDs(tpkg.SortedRand32BytArray(2)).
+		WithRemotePoW(context.Background(), nodeAPI).
+		Build()
+	require.True(t, errors.Is(err, iotago.ErrRemotePoWUnavailable))
+}
+
+func TestMessageBuilder_WithTipSelector(t *testing.T) {
+	parents := tpkg.SortedRand32BytArray(2)
+	var selections int
+	tipSelector := iotago.TipSelectorFunc(func(ctx context.Context) (iotago.MessageIDs, error) {
+		selections++
+		return parents, nil
+	})
+
+	builder := iotago.NewMessageBuilder().
+		ParentsMessageIDs(tpkg.SortedRand32BytArray(2)).
+		WithTipSelector(context.Background(), tipSelector).
+		WithNonce(1337)
+	// the tips are only selected on Build
+	require.Zero(t, selections)
+
+	msg, err := builder.Build()
+	require.NoError(t, err)
+	require.Equal(t, 1, selections)
+	require.EqualValues(t, parents, msg.Parents)
+
+	errTipSelection := errors.New("tip selection failed")
+	_, err = iotago.NewMessageBuilder().
+		WithTipSelector(context.Background(), iotago.TipSelectorFunc(func(ctx context.Context) (iotago.MessageIDs, error) {
+			return nil, errTipSelection
+		})).
+		Build()
+	require.True(t, errors.Is(err, errTipSelection))
+}
+
+func TestMessageBuilder_WithMinPoWScore(t *testing.T) {
+	const targetPoWScore float64 = 500
+
+	parents := tpkg.SortedRand32BytArray(2)
+
+	msg, err := iotago.NewMessageBuilder().
+		ParentsMessageIDs(parents).
+		WithLocalPoW(context.Background(), targetPoWScore, 1).
+		WithMinPoWScore(targetPoWScore).
+		Build()
+	require.NoError(t, err)
+
+	powScore, err := msg.PoWScore()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, powScore, targetPoWScore)
+
+	// a nonce not meeting the minimum is rejected
+	_, err = iotago.NewMessageBuilder().
+		ParentsMessageIDs(parents).
+		WithNonce(msg.Nonce).
+		WithMinPoWScore(powScore + 1).
+		Build()
+	require.True(t, errors.Is(err, iotago.ErrMessagePoWScoreTooLow))
+	require.Contains(t, err.Error(), fmt.Sprintf("%.2f", powScore))
+	require.Contains(t, err.Error(), fmt.Sprintf("%.2f", powScore+1))
+}
+
+func TestMessageBuilder_ExceedsMaxSize(t *testing.T) {
+	parents := tpkg.SortedRand32BytArray(2)
+
+	// the indexation itself fits but not the message containing it
+	_, err := iotago.NewMessageBuilder().
+		Payload(&iotago.Indexation{Index: []byte("hello world"), Data: tpkg.RandBytes(iotago.MessageBinSerializedMaxSize - 8)}).
+		ParentsMessageIDs(parents).
+		WithLocalPoW(context.Background(), 500, 1).
+		Build()
+	require.True(t, errors.Is(err, iotago.ErrMessageExceedsMaxSize))
+	require.Contains(t, err.Error(), fmt.Sprintf("the max is %d bytes", iotago.MessageBinSerializedMaxSize))
+}
+
+func TestMessageBuilder_WithNonce(t *testing.T) {
+	parents := tpkg.SortedRand32BytArray(2)
+	build := func() *iotago.Message {
+		msg, err := iotago.NewMessageBuilder().
+			NetworkIDFromString("alphanet@1").
+			ParentsMessageIDs(parents).
+			WithLocalPoW(context.Background(), 1<<20, 1).
+			WithNonce(1337).
+			Build()
+		require.NoError(t, err)
+		return msg
+	}
+
+	msg1, msg2 := build(), build()
+	require.EqualValues(t, 1337, msg1.Nonce)
+
+	msgData1, err := msg1.Serialize(serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+	msgData2, err := msg2.Serialize(serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+	require.Equal(t, msgData1, msgData2)
+	require.Equal(t, msg1.MustID(), msg2.MustID())
+}
+
+func TestMessageBuilder_BuildAndID(t *testing.T) {
+	msg, msgID, err := iotago.NewMessageBuilder().
+		ParentsMessageIDs(tpkg.SortedRand32BytArray(2)).
+		WithNonce(1337).
+		BuildAndID()
+	require.NoError(t, err)
+	require.Equal(t, msg.MustID(), *msgID)
+
+	_, _, err = iotago.NewMessageBuilder().
+		ParentsMessageIDs(tpkg.SortedRand32BytArray(2)).
+		BuildAndID()
+	require.True(t, errors.Is(err, iotago.ErrMessageNonceNotSet))
+}
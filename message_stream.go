@@ -0,0 +1,124 @@
+package iotago
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/iotaledger/hive.go/serializer"
+)
+
+// MessageStreamLengthPrefixSize defines the size of the length prefix preceding each message within a message stream.
+const MessageStreamLengthPrefixSize = serializer.UInt32ByteSize
+
+var (
+	// ErrMessageStreamTruncated gets returned if a message stream ends within a record.
+	ErrMessageStreamTruncated = errors.New("message stream truncated")
+)
+
+// NewMessageReader creates a new MessageReader reading messages from the given io.Reader.
+// Each message is deserialized using the given serializer.DeSerializationMode.
+func NewMessageReader(r io.Reader, deSeriMode serializer.DeSerializationMode) *MessageReader {
+	return &MessageReader{r: r, deSeriMode: deSeriMode}
+}
+
+// MessageReader reads messages from an io.Reader in which each serialized message is
+// prefixed by its length as a little endian uint32.
+type MessageReader struct {
+	r          io.Reader
+	deSeriMode serializer.DeSerializationMode
+	// the amount of records read so far.
+	read int
+}
+
+// Next reads the next message from the stream. It returns io.EOF if the stream ends after the last record
+// and an error wrapping ErrMessageStreamTruncated if the stream ends within a record.
+func (mr *MessageReader) Next() (*Message, error) {
+	var lengthPrefix [MessageStreamLengthPrefixSize]byte
+	if _, err := io.ReadFull(mr.r, lengthPrefix[:]); err != nil {
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil, io.EOF
+		case errors.Is(err, io.ErrUnexpectedEOF):
+			return nil, fmt.Errorf("%w: length prefix of record %d is incomplete", ErrMessageStreamTruncated, mr.read)
+		default:
+			return nil, fmt.Errorf("unable to read length prefix of record %d: %w", mr.read, err)
+		}
+	}
+
+	length := binary.LittleEndian.Uint32(lengthPrefix[:])
+	if length > MessageBinSerializedMaxSize {
+		return nil, fmt.Errorf("%w: record %d has a length of %d which exceeds the max message size of %d", serializer.ErrDeserializationLengthInvalid, mr.read, length, MessageBinSerializedMaxSize)
+	}
+
+	data := make([]byte, length)
+	if n, err := io.ReadFull(mr.r, data); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("%w: record %d has a length of %d but only %d bytes are left", ErrMessageStreamTruncated, mr.read, length, n)
+		}
+		return nil, fmt.Errorf("unable to read record %d: %w", mr.read, err)
+	}
+
+	msg := &Message{}
+	if _, err := msg.Deserialize(data, mr.deSeriMode); err != nil {
+		return nil, fmt.Errorf("unable to deserialize message of record %d: %w", mr.read, err)
+	}
+	mr.read++
+
+	return msg, nil
+}
+
+// NewMessageWriter creates a new MessageWriter writing messages to the given io.Writer.
+// Each message is serialized using the given serializer.DeSerializationMode.
+func NewMessageWriter(w io.Writer, deSeriMode serializer.DeSerializationMode) *MessageWriter {
+	return &MessageWriter{w: w, bufW: bufio.NewWriter(w), deSeriMode: deSeriMode}
+}
+
+// MessageWriter writes messages to an io.Writer in the framing read by MessageReader.
+// Writes are buffered, therefore Flush or Close must be called after the last message was written.
+type MessageWriter struct {
+	w          io.Writer
+	bufW       *bufio.Writer
+	deSeriMode serializer.DeSerializationMode
+}
+
+// Write serializes the given message and writes it prefixed by its length. Like MessageReader, it rejects messages
+// exceeding MessageBinSerializedMaxSize with an error wrapping ErrMessageExceedsMaxSize, regardless of the
+// serializer.DeSerializationMode.
+func (mw *MessageWriter) Write(msg *Message) error {
+	data, err := msg.Serialize(mw.deSeriMode)
+	if err != nil {
+		return fmt.Errorf("unable to serialize message: %w", err)
+	}
+	if len(data) > MessageBinSerializedMaxSize {
+		return fmt.Errorf("%w: message has a size of %d bytes but the max is %d bytes", ErrMessageExceedsMaxSize, len(data), MessageBinSerializedMaxSize)
+	}
+
+	var lengthPrefix [MessageStreamLengthPrefixSize]byte
+	binary.LittleEndian.PutUint32(lengthPrefix[:], uint32(len(data)))
+	if _, err := mw.bufW.Write(lengthPrefix[:]); err != nil {
+		return fmt.Errorf("unable to write length prefix: %w", err)
+	}
+	if _, err := mw.bufW.Write(data); err != nil {
+		return fmt.Errorf("unable to write message: %w", err)
+	}
+	return nil
+}
+
+// Flush writes any buffered data to the underlying io.Writer.
+func (mw *MessageWriter) Flush() error {
+	return mw.bufW.Flush()
+}
+
+// Close flushes the MessageWriter and closes the underlying io.Writer if it is an io.Closer.
+func (mw *MessageWriter) Close() error {
+	if err := mw.Flush(); err != nil {
+		return err
+	}
+	if closer, ok := mw.w.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
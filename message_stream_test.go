@@ -0,0 +1,109 @@
+package iotago_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+	"github.com/stretchr/testify/require"
+)
+
+// writes the given message data prefixed by its length.
+func writeMessageRecord(buf *bytes.Buffer, msgData []byte) {
+	_ = binary.Write(buf, binary.LittleEndian, uint32(len(msgData)))
+	buf.Write(msgData)
+}
+
+func TestMessageReader(t *testing.T) {
+	var buf bytes.Buffer
+	var msgs []*iotago.Message
+	for _, payloadType := range []uint32{iotago.TransactionPayloadTypeID, iotago.IndexationPayloadTypeID, iotago.MilestonePayloadTypeID} {
+		msg, msgData := tpkg.RandMessage(payloadType)
+		writeMessageRecord(&buf, msgData)
+		msgs = append(msgs, msg)
+	}
+
+	mr := iotago.NewMessageReader(&buf, serializer.DeSeriModePerformValidation)
+	for _, msg := range msgs {
+		readMsg, err := mr.Next()
+		require.NoError(t, err)
+		require.EqualValues(t, msg, readMsg)
+	}
+
+	_, err := mr.Next()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestMessageReader_Truncated(t *testing.T) {
+	_, msgData := tpkg.RandMessage(iotago.IndexationPayloadTypeID)
+
+	var buf bytes.Buffer
+	writeMessageRecord(&buf, msgData)
+	writeMessageRecord(&buf, msgData)
+	data := buf.Bytes()
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"truncated record", data[:len(data)-1]},
+		{"truncated length prefix", data[:len(data)/2+2]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mr := iotago.NewMessageReader(bytes.NewReader(tt.data), serializer.DeSeriModePerformValidation)
+			_, err := mr.Next()
+			require.NoError(t, err)
+			_, err = mr.Next()
+			require.True(t, errors.Is(err, iotago.ErrMessageStreamTruncated))
+		})
+	}
+
+	var tooLarge bytes.Buffer
+	_ = binary.Write(&tooLarge, binary.LittleEndian, uint32(iotago.MessageBinSerializedMaxSize+1))
+	_, err := iotago.NewMessageReader(&tooLarge, serializer.DeSeriModePerformValidation).Next()
+	require.True(t, errors.Is(err, serializer.ErrDeserializationLengthInvalid))
+}
+
+func TestMessageWriter(t *testing.T) {
+	var buf bytes.Buffer
+	mw := iotago.NewMessageWriter(&buf, serializer.DeSeriModePerformValidation)
+
+	var msgs []*iotago.Message
+	for _, payloadType := range []uint32{iotago.TransactionPayloadTypeID, iotago.IndexationPayloadTypeID, iotago.MilestonePayloadTypeID} {
+		msg, _ := tpkg.RandMessage(payloadType)
+		require.NoError(t, mw.Write(msg))
+		msgs = append(msgs, msg)
+	}
+	require.NoError(t, mw.Close())
+
+	mr := iotago.NewMessageReader(&buf, serializer.DeSeriModePerformValidation)
+	for _, msg := range msgs {
+		readMsg, err := mr.Next()
+		require.NoError(t, err)
+		require.EqualValues(t, msg, readMsg)
+	}
+
+	_, err := mr.Next()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestMessageWriter_ExceedsMaxSize(t *testing.T) {
+	var buf bytes.Buffer
+	mw := iotago.NewMessageWriter(&buf, serializer.DeSeriModeNoValidation)
+
+	msg := &iotago.Message{
+		NetworkID: 1337,
+		Parents:   tpkg.SortedRand32BytArray(1),
+		Payload:   &iotago.Indexation{Index: []byte("index"), Data: tpkg.RandBytes(iotago.MessageBinSerializedMaxSize)},
+	}
+	require.True(t, errors.Is(mw.Write(msg), iotago.ErrMessageExceedsMaxSize))
+	require.NoError(t, mw.Flush())
+	require.Zero(t, buf.Len())
+}
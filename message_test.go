@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/iotaledger/hive.go/serializer"
 	"github.com/iotaledger/iota.go/v2/tpkg"
 	"testing"
@@ -79,6 +80,15 @@ func TestMessage_Serialize(t *testing.T) {
 	}
 }
 
+func TestMessage_ValidateSize(t *testing.T) {
+	msg, msgData := tpkg.RandMessage(iotago.IndexationPayloadTypeID)
+	assert.NoError(t, msg.ValidateSize(len(msgData)))
+
+	err := msg.ValidateSize(len(msgData) - 1)
+	assert.True(t, errors.Is(err, iotago.ErrMessageExceedsMaxSize))
+	assert.Contains(t, err.Error(), fmt.Sprintf("size of %d bytes but the max is %d bytes", len(msgData), len(msgData)-1))
+}
+
 func TestMessage_UnmarshalJSON(t *testing.T) {
 	data := `
 		{
@@ -158,3 +168,44 @@ func TestMessage_UnmarshalJSON(t *testing.T) {
 	assert.Nil(t, msgMinimal.Payload)
 	assert.Equal(t, msgMinimal.Nonce, uint64(0))
 }
+
+func TestMessage_CBOR(t *testing.T) {
+	type test struct {
+		name string
+		msg  *iotago.Message
+	}
+
+	tests := []test{
+		func() test {
+			msg, _ := tpkg.RandMessage(iotago.TransactionPayloadTypeID)
+			return test{"transaction payload", msg}
+		}(),
+		func() test {
+			msg, _ := tpkg.RandMessage(iotago.IndexationPayloadTypeID)
+			return test{"indexation payload", msg}
+		}(),
+		{"no payload", &iotago.Message{NetworkID: 1337, Parents: tpkg.SortedRand32BytArray(2), Nonce: 133945865838}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cborData, err := tt.msg.MarshalCBOR()
+			assert.NoError(t, err)
+
+			jsonData, err := tt.msg.MarshalJSON()
+			assert.NoError(t, err)
+			assert.Less(t, len(cborData), len(jsonData))
+
+			msg := &iotago.Message{}
+			assert.NoError(t, msg.UnmarshalCBOR(cborData))
+			assert.EqualValues(t, tt.msg, msg)
+
+			// the encoding is deterministic
+			cborDataAgain, err := msg.MarshalCBOR()
+			assert.NoError(t, err)
+			assert.Equal(t, cborData, cborDataAgain)
+
+			assert.True(t, errors.Is(msg.UnmarshalCBOR(cborData[:len(cborData)-1]), iotago.ErrInvalidCBOR))
+			assert.True(t, errors.Is(msg.UnmarshalCBOR(append(cborData, 0)), iotago.ErrInvalidCBOR))
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package iotago
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+)
+
+// NewMilestoneBuilder creates a new MilestoneBuilder for a Milestone with the given index.
+func NewMilestoneBuilder(index uint32) *MilestoneBuilder {
+	return &MilestoneBuilder{
+		ms: &Milestone{Index: index},
+	}
+}
+
+// MilestoneBuilder is used to easily build up a Milestone.
+type MilestoneBuilder struct {
+	ms          *Milestone
+	signingFunc MilestoneSigningFunc
+}
+
+// Timestamp sets the time at which the milestone is issued.
+func (mb *MilestoneBuilder) Timestamp(timestamp uint64) *MilestoneBuilder {
+	mb.ms.Timestamp = timestamp
+	return mb
+}
+
+// Parents sets the parents of the milestone. The parents are sorted lexically on Build.
+func (mb *MilestoneBuilder) Parents(parents MilestoneParentMessageIDs) *MilestoneBuilder {
+	mb.ms.Parents = parents
+	return mb
+}
+
+// InclusionMerkleProof sets the inclusion merkle proof of the transactions newly confirmed by the milestone.
+func (mb *MilestoneBuilder) InclusionMerkleProof(proof MilestoneInclusionMerkleProof) *MilestoneBuilder {
+	mb.ms.InclusionMerkleProof = proof
+	return mb
+}
+
+// NextPoWScore sets the minimum PoW score which applies from the given milestone index onwards.
+func (mb *MilestoneBuilder) NextPoWScore(nextPoWScore uint32, nextPoWScoreMilestoneIndex uint32) *MilestoneBuilder {
+	mb.ms.NextPoWScore = nextPoWScore
+	mb.ms.NextPoWScoreMilestoneIndex = nextPoWScoreMilestoneIndex
+	return mb
+}
+
+// Receipt sets the receipt embedded in the milestone.
+func (mb *MilestoneBuilder) Receipt(receipt *Receipt) *MilestoneBuilder {
+	mb.ms.Receipt = receipt
+	return mb
+}
+
+// Signer sets the public keys of the milestone and the MilestoneSigningFunc producing their signatures on Build.
+// This function overrides any previously set signer.
+func (mb *MilestoneBuilder) Signer(pubKeys []MilestonePublicKey, signingFunc MilestoneSigningFunc) *MilestoneBuilder {
+	mb.ms.PublicKeys = pubKeys
+	mb.signingFunc = signingFunc
+	return mb
+}
+
+// SignWithKeys lets the milestone be signed by every given Ed25519 private key on Build.
+// This function overrides any previously set signer.
+func (mb *MilestoneBuilder) SignWithKeys(prvKeys ...ed25519.PrivateKey) *MilestoneBuilder {
+	pubKeys := make([]MilestonePublicKey, len(prvKeys))
+	keyMapping := make(MilestonePublicKeyMapping, len(prvKeys))
+	for i, prvKey := range prvKeys {
+		copy(pubKeys[i][:], prvKey.Public().(ed25519.PublicKey))
+		keyMapping[pubKeys[i]] = prvKey
+	}
+	return mb.Signer(pubKeys, InMemoryEd25519MilestoneSigner(keyMapping))
+}
+
+// Build sorts the parents and public keys, signs the Milestone with the configured signer and then
+// validates it by serializing it and verifying all of its signatures.
+func (mb *MilestoneBuilder) Build() (*Milestone, error) {
+	if len(mb.ms.PublicKeys) < MinPublicKeysInAMilestone || mb.signingFunc == nil {
+		return nil, fmt.Errorf("unable to build milestone: %w", ErrMilestoneTooFewPublicKeys)
+	}
+
+	sort.Slice(mb.ms.Parents, func(i, j int) bool {
+		return bytes.Compare(mb.ms.Parents[i][:], mb.ms.Parents[j][:]) < 0
+	})
+	sort.Slice(mb.ms.PublicKeys, func(i, j int) bool {
+		return bytes.Compare(mb.ms.PublicKeys[i][:], mb.ms.PublicKeys[j][:]) < 0
+	})
+
+	if err := mb.ms.Sign(mb.signingFunc); err != nil {
+		return nil, fmt.Errorf("unable to build milestone: %w", err)
+	}
+
+	if _, err := mb.ms.Serialize(serializer.DeSeriModePerformValidation); err != nil {
+		return nil, fmt.Errorf("unable to build milestone: %w", err)
+	}
+
+	pubKeySet := make(MilestonePublicKeySet, len(mb.ms.PublicKeys))
+	for _, pubKey := range mb.ms.PublicKeys {
+		pubKeySet[pubKey] = struct{}{}
+	}
+	if err := mb.ms.VerifySignatures(len(mb.ms.PublicKeys), pubKeySet); err != nil {
+		return nil, fmt.Errorf("unable to build milestone: %w", err)
+	}
+
+	return mb.ms, nil
+}
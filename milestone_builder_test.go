@@ -0,0 +1,89 @@
+package iotago_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMilestoneBuilder(t *testing.T) {
+	prvKey1, prvKey2 := tpkg.RandEd25519PrivateKey(), tpkg.RandEd25519PrivateKey()
+	pubKeySet := iotago.MilestonePublicKeySet{}
+	for _, prvKey := range []ed25519.PrivateKey{prvKey1, prvKey2} {
+		var pubKey iotago.MilestonePublicKey
+		copy(pubKey[:], prvKey.Public().(ed25519.PublicKey))
+		pubKeySet[pubKey] = struct{}{}
+	}
+
+	type test struct {
+		name     string
+		builder  *iotago.MilestoneBuilder
+		buildErr error
+	}
+
+	tests := []test{
+		{
+			name: "ok",
+			builder: iotago.NewMilestoneBuilder(1337).
+				Timestamp(uint64(time.Now().Unix())).
+				Parents(tpkg.SortedRand32BytArray(3)).
+				InclusionMerkleProof(tpkg.Rand32ByteArray()).
+				NextPoWScore(2000, 1500).
+				SignWithKeys(prvKey1, prvKey2),
+		},
+		{
+			name: "ok - unsorted parents",
+			builder: iotago.NewMilestoneBuilder(1337).
+				Parents(iotago.MilestoneParentMessageIDs{{2}, {1}}).
+				SignWithKeys(prvKey2, prvKey1),
+		},
+		{
+			name: "err - no signer",
+			builder: iotago.NewMilestoneBuilder(1337).
+				Parents(tpkg.SortedRand32BytArray(1)),
+			buildErr: iotago.ErrMilestoneTooFewPublicKeys,
+		},
+		{
+			name: "err - missing private key",
+			builder: iotago.NewMilestoneBuilder(1337).
+				Parents(tpkg.SortedRand32BytArray(1)).
+				Signer(tpkg.SortedRand32BytArray(2), iotago.InMemoryEd25519MilestoneSigner(iotago.MilestonePublicKeyMapping{})),
+			buildErr: iotago.ErrMilestoneInMemorySignerPrivateKeyMissing,
+		},
+		{
+			name: "err - next pow score without index",
+			builder: iotago.NewMilestoneBuilder(1337).
+				Parents(tpkg.SortedRand32BytArray(1)).
+				NextPoWScore(2000, 0).
+				SignWithKeys(prvKey1),
+			buildErr: iotago.ErrMilestoneInvalidMinPoWScoreValues,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ms, err := test.builder.Build()
+			if test.buildErr != nil {
+				assert.True(t, errors.Is(err, test.buildErr))
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, 1337, ms.Index)
+			require.NoError(t, ms.VerifySignatures(2, pubKeySet))
+
+			msData, err := ms.Serialize(serializer.DeSeriModePerformValidation)
+			require.NoError(t, err)
+			desMs := &iotago.Milestone{}
+			_, err = desMs.Deserialize(msData, serializer.DeSeriModePerformValidation)
+			require.NoError(t, err)
+			require.EqualValues(t, ms, desMs)
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package iotago
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// ErrMultiNodeClientNoNodes gets returned when a MultiNodeClient is used without any nodes.
+	ErrMultiNodeClientNoNodes = errors.New("multi node client has no nodes")
+	// ErrMultiNodeClientAllNodesFailed gets returned when a call failed on every node of a MultiNodeClient.
+	ErrMultiNodeClientAllNodesFailed = errors.New("call failed on all nodes")
+)
+
+// allNodesFailedError is returned when a call failed on every node. It matches ErrMultiNodeClientAllNodesFailed
+// and any error any of the nodes failed with.
+type allNodesFailedError struct {
+	nodeURLs []string
+	errs     []error
+}
+
+// records that the call failed on the given node with the given error.
+func (e *allNodesFailedError) add(node *NodeHTTPAPIClient, err error) {
+	e.nodeURLs = append(e.nodeURLs, node.BaseURL)
+	e.errs = append(e.errs, err)
+}
+
+func (e *allNodesFailedError) Error() string {
+	failures := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		failures[i] = fmt.Sprintf("%s: %s", e.nodeURLs[i], err)
+	}
+	return fmt.Sprintf("%s: %s", ErrMultiNodeClientAllNodesFailed, strings.Join(failures, "; "))
+}
+
+func (e *allNodesFailedError) Is(target error) bool {
+	if target == ErrMultiNodeClientAllNodesFailed {
+		return true
+	}
+	for _, err := range e.errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// MultiNodeSelectionPolicy returns the order in which the given nodes are tried by a MultiNodeClient for a call.
+type MultiNodeSelectionPolicy func(ctx context.Context, nodes []*NodeHTTPAPIClient) []*NodeHTTPAPIClient
+
+// RoundRobinSelectionPolicy returns a MultiNodeSelectionPolicy which starts every call at the node following
+// the one the previous call started at, thereby spreading the load evenly across the nodes.
+func RoundRobinSelectionPolicy() MultiNodeSelectionPolicy {
+	var next uint32
+	return func(_ context.Context, nodes []*NodeHTTPAPIClient) []*NodeHTTPAPIClient {
+		if len(nodes) == 0 {
+			return nil
+		}
+		start := int((atomic.AddUint32(&next, 1) - 1) % uint32(len(nodes)))
+		ordered := make([]*NodeHTTPAPIClient, 0, len(nodes))
+		ordered = append(ordered, nodes[start:]...)
+		return append(ordered, nodes[:start]...)
+	}
+}
+
+// HealthiestFirstSelectionPolicy returns a MultiNodeSelectionPolicy which queries the health of all nodes
+// on every call and tries healthy nodes before unhealthy or unreachable ones.
+// Nodes within the same group keep their configured order.
+func HealthiestFirstSelectionPolicy() MultiNodeSelectionPolicy {
+	return func(ctx context.Context, nodes []*NodeHTTPAPIClient) []*NodeHTTPAPIClient {
+		healthy := make([]bool, len(nodes))
+		var wg sync.WaitGroup
+		for i, node := range nodes {
+			wg.Add(1)
+			go func(i int, node *NodeHTTPAPIClient) {
+				defer wg.Done()
+				healthy[i], _ = node.Health(ctx)
+			}(i, node)
+		}
+		wg.Wait()
+
+		ordered := make([]*NodeHTTPAPIClient, 0, len(nodes))
+		var unhealthy []*NodeHTTPAPIClient
+		for i, node := range nodes {
+			if healthy[i] {
+				ordered = append(ordered, node)
+				continue
+			}
+			unhealthy = append(unhealthy, node)
+		}
+		return append(ordered, unhealthy...)
+	}
+}
+
+var defaultMultiNodeClientOptions = []MultiNodeClientOption{
+	WithMultiNodeClientBroadcastSubmissions(false),
+}
+
+// MultiNodeClientOptions define options for the MultiNodeClient.
+type MultiNodeClientOptions struct {
+	// The policy defining the order in which the nodes are tried.
+	selectionPolicy MultiNodeSelectionPolicy
+	// Whether messages are submitted to all nodes.
+	broadcastSubmissions bool
+}
+
+// applies the given MultiNodeClientOption.
+func (mo *MultiNodeClientOptions) apply(opts ...MultiNodeClientOption) {
+	for _, opt := range opts {
+		opt(mo)
+	}
+}
+
+// WithMultiNodeClientSelectionPolicy sets the MultiNodeSelectionPolicy defining the order in which the nodes are tried.
+// Defaults to a RoundRobinSelectionPolicy per MultiNodeClient.
+func WithMultiNodeClientSelectionPolicy(selectionPolicy MultiNodeSelectionPolicy) MultiNodeClientOption {
+	return func(opts *MultiNodeClientOptions) {
+		opts.selectionPolicy = selectionPolicy
+	}
+}
+
+// WithMultiNodeClientBroadcastSubmissions sets whether SubmitMessage submits messages to all nodes
+// instead of only to the first node accepting them.
+func WithMultiNodeClientBroadcastSubmissions(broadcast bool) MultiNodeClientOption {
+	return func(opts *MultiNodeClientOptions) {
+		opts.broadcastSubmissions = broadcast
+	}
+}
+
+// MultiNodeClientOption is a function setting a MultiNodeClient option.
+type MultiNodeClientOption func(opts *MultiNodeClientOptions)
+
+// NewMultiNodeClient returns a new MultiNodeClient using the given nodes.
+func NewMultiNodeClient(nodes []*NodeHTTPAPIClient, opts ...MultiNodeClientOption) *MultiNodeClient {
+	// the round robin policy is stateful and therefore not shared across clients via the default options
+	options := &MultiNodeClientOptions{selectionPolicy: RoundRobinSelectionPolicy()}
+	options.apply(defaultMultiNodeClientOptions...)
+	options.apply(opts...)
+
+	return &MultiNodeClient{
+		nodes: nodes,
+		opts:  options,
+	}
+}
+
+// MultiNodeClient distributes calls across multiple nodes in the order given by its MultiNodeSelectionPolicy
+// and fails over to the next node whenever a call fails on a node.
+type MultiNodeClient struct {
+	nodes []*NodeHTTPAPIClient
+	// holds the MultiNodeClient options.
+	opts *MultiNodeClientOptions
+}
+
+// Nodes returns the nodes of the MultiNodeClient.
+func (mc *MultiNodeClient) Nodes() []*NodeHTTPAPIClient {
+	return mc.nodes
+}
+
+// Do calls f with the nodes in the order given by the MultiNodeSelectionPolicy until a call succeeds.
+// Any error returned by f causes a fail over to the next node, unless the context is done.
+// If f fails on every node, an error listing the errors is returned which matches ErrMultiNodeClientAllNodesFailed
+// as well as any of the errors via errors.Is.
+func (mc *MultiNodeClient) Do(ctx context.Context, f func(ctx context.Context, node *NodeHTTPAPIClient) error) error {
+	if len(mc.nodes) == 0 {
+		return ErrMultiNodeClientNoNodes
+	}
+
+	allFailed := &allNodesFailedError{}
+	for _, node := range mc.opts.selectionPolicy(ctx, mc.nodes) {
+		err := f(ctx, node)
+		if err == nil {
+			return nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		allFailed.add(node, err)
+	}
+	return allFailed
+}
+
+// Health returns whether any of the nodes is healthy.
+func (mc *MultiNodeClient) Health(ctx context.Context) (bool, error) {
+	var healthy bool
+	err := mc.Do(ctx, func(ctx context.Context, node *NodeHTTPAPIClient) error {
+		var err error
+		if healthy, err = node.Health(ctx); err == nil && !healthy {
+			return errors.New("node is not healthy")
+		}
+		return err
+	})
+	if errors.Is(err, ErrMultiNodeClientAllNodesFailed) {
+		return false, nil
+	}
+	return healthy, err
+}
+
+// Info gets the info of the first node answering. See NodeHTTPAPIClient.Info.
+func (mc *MultiNodeClient) Info(ctx context.Context) (*NodeInfoResponse, error) {
+	var res *NodeInfoResponse
+	err := mc.Do(ctx, func(ctx context.Context, node *NodeHTTPAPIClient) error {
+		var err error
+		res, err = node.Info(ctx)
+		return err
+	})
+	return res, err
+}
+
+// Tips gets the tips of the first node answering. See NodeHTTPAPIClient.Tips.
+func (mc *MultiNodeClient) Tips(ctx context.Context) (*NodeTipsResponse, error) {
+	var res *NodeTipsResponse
+	err := mc.Do(ctx, func(ctx context.Context, node *NodeHTTPAPIClient) error {
+		var err error
+		res, err = node.Tips(ctx)
+		return err
+	})
+	return res, err
+}
+
+// MessageMetadataByMessageID gets the metadata of a message by its ID. See NodeHTTPAPIClient.MessageMetadataByMessageID.
+func (mc *MultiNodeClient) MessageMetadataByMessageID(ctx context.Context, msgID MessageID) (*MessageMetadataResponse, error) {
+	var res *MessageMetadataResponse
+	err := mc.Do(ctx, func(ctx context.Context, node *NodeHTTPAPIClient) error {
+		var err error
+		res, err = node.MessageMetadataByMessageID(ctx, msgID)
+		return err
+	})
+	return res, err
+}
+
+// OutputByID gets an output by its ID. See NodeHTTPAPIClient.OutputByID.
+func (mc *MultiNodeClient) OutputByID(ctx context.Context, utxoID UTXOInputID) (*NodeOutputResponse, error) {
+	var res *NodeOutputResponse
+	err := mc.Do(ctx, func(ctx context.Context, node *NodeHTTPAPIClient) error {
+		var err error
+		res, err = node.OutputByID(ctx, utxoID)
+		return err
+	})
+	return res, err
+}
+
+// OutputIDsByEd25519Address gets the output IDs by the given Ed25519 address. See NodeHTTPAPIClient.OutputIDsByEd25519Address.
+func (mc *MultiNodeClient) OutputIDsByEd25519Address(ctx context.Context, addr *Ed25519Address, includeSpentOutputs bool) (*AddressOutputsResponse, error) {
+	var res *AddressOutputsResponse
+	err := mc.Do(ctx, func(ctx context.Context, node *NodeHTTPAPIClient) error {
+		var err error
+		res, err = node.OutputIDsByEd25519Address(ctx, addr, includeSpentOutputs)
+		return err
+	})
+	return res, err
+}
+
+// OutputsByEd25519Address gets the outputs by the given Ed25519 address. See NodeHTTPAPIClient.OutputsByEd25519Address.
+// The output IDs and the outputs are queried from the same node.
+func (mc *MultiNodeClient) OutputsByEd25519Address(ctx context.Context, addr *Ed25519Address, includeSpentOutputs bool) (*AddressOutputsResponse, map[*UTXOInput]Output, error) {
+	var res *AddressOutputsResponse
+	var outputs map[*UTXOInput]Output
+	err := mc.Do(ctx, func(ctx context.Context, node *NodeHTTPAPIClient) error {
+		var err error
+		res, outputs, err = node.OutputsByEd25519Address(ctx, addr, includeSpentOutputs)
+		return err
+	})
+	return res, outputs, err
+}
+
+// SubmitMessage submits the given Message to the first node accepting it. See NodeHTTPAPIClient.SubmitMessage.
+// If broadcasting was enabled via WithMultiNodeClientBroadcastSubmissions, the Message is submitted to all nodes
+// concurrently instead and the Message returned by the first node accepting it is returned.
+// The submission only fails if no node accepts the Message. Note that when broadcasting a Message without parents
+// or nonce, every node fills in its own tips and does its own proof-of-work, so that the nodes end up with different
+// Messages and only the one of the first accepting node is returned.
+func (mc *MultiNodeClient) SubmitMessage(ctx context.Context, m *Message) (*Message, error) {
+	if !mc.opts.broadcastSubmissions {
+		var res *Message
+		err := mc.Do(ctx, func(ctx context.Context, node *NodeHTTPAPIClient) error {
+			var err error
+			res, err = node.SubmitMessage(ctx, m)
+			return err
+		})
+		return res, err
+	}
+
+	if len(mc.nodes) == 0 {
+		return nil, ErrMultiNodeClientNoNodes
+	}
+
+	type result struct {
+		msg *Message
+		err error
+	}
+	results := make([]result, len(mc.nodes))
+	var wg sync.WaitGroup
+	for i, node := range mc.nodes {
+		wg.Add(1)
+		go func(i int, node *NodeHTTPAPIClient) {
+			defer wg.Done()
+			results[i].msg, results[i].err = node.SubmitMessage(ctx, m)
+		}(i, node)
+	}
+	wg.Wait()
+
+	allFailed := &allNodesFailedError{}
+	for i, res := range results {
+		if res.err == nil {
+			return res.msg, nil
+		}
+		allFailed.add(mc.nodes[i], res.err)
+	}
+	return nil, allFailed
+}
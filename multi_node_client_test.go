@@ -0,0 +1,183 @@
+package iotago_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const otherNodeAPIUrl = "http://127.0.0.1:14266"
+
+func TestRoundRobinSelectionPolicy(t *testing.T) {
+	nodes := []*iotago.NodeHTTPAPIClient{
+		iotago.NewNodeHTTPAPIClient(nodeAPIUrl),
+		iotago.NewNodeHTTPAPIClient(otherNodeAPIUrl),
+	}
+
+	policy := iotago.RoundRobinSelectionPolicy()
+	require.Equal(t, []*iotago.NodeHTTPAPIClient{nodes[0], nodes[1]}, policy(context.Background(), nodes))
+	require.Equal(t, []*iotago.NodeHTTPAPIClient{nodes[1], nodes[0]}, policy(context.Background(), nodes))
+	require.Equal(t, []*iotago.NodeHTTPAPIClient{nodes[0], nodes[1]}, policy(context.Background(), nodes))
+	require.Empty(t, policy(context.Background(), nil))
+}
+
+func TestHealthiestFirstSelectionPolicy(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteHealth).
+		Reply(503)
+
+	gock.New(otherNodeAPIUrl).
+		Get(iotago.NodeAPIRouteHealth).
+		Reply(200)
+
+	nodes := []*iotago.NodeHTTPAPIClient{
+		iotago.NewNodeHTTPAPIClient(nodeAPIUrl),
+		iotago.NewNodeHTTPAPIClient(otherNodeAPIUrl),
+	}
+
+	policy := iotago.HealthiestFirstSelectionPolicy()
+	require.Equal(t, []*iotago.NodeHTTPAPIClient{nodes[1], nodes[0]}, policy(context.Background(), nodes))
+	require.True(t, gock.IsDone())
+}
+
+func TestMultiNodeClient_Info(t *testing.T) {
+	defer gock.Off()
+
+	originInfo := &iotago.NodeInfoResponse{
+		Name:      "HORNET",
+		Version:   "1.0.0",
+		IsHealthy: true,
+		NetworkID: "alphanet@1",
+		Bech32HRP: "atoi",
+	}
+
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteInfo).
+		Reply(500).
+		JSON(&iotago.HTTPErrorResponseEnvelope{})
+
+	gock.New(otherNodeAPIUrl).
+		Get(iotago.NodeAPIRouteInfo).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: originInfo})
+
+	multiNodeClient := iotago.NewMultiNodeClient([]*iotago.NodeHTTPAPIClient{
+		iotago.NewNodeHTTPAPIClient(nodeAPIUrl),
+		iotago.NewNodeHTTPAPIClient(otherNodeAPIUrl),
+	})
+
+	info, err := multiNodeClient.Info(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, originInfo, info)
+
+	// every node fails
+	gock.New(otherNodeAPIUrl).
+		Get(iotago.NodeAPIRouteInfo).
+		Reply(500).
+		JSON(&iotago.HTTPErrorResponseEnvelope{})
+
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteInfo).
+		Reply(500).
+		JSON(&iotago.HTTPErrorResponseEnvelope{})
+
+	_, err = multiNodeClient.Info(context.Background())
+	assert.True(t, errors.Is(err, iotago.ErrMultiNodeClientAllNodesFailed))
+
+	_, err = iotago.NewMultiNodeClient(nil).Info(context.Background())
+	assert.True(t, errors.Is(err, iotago.ErrMultiNodeClientNoNodes))
+}
+
+func TestMultiNodeClient_SubmitMessage(t *testing.T) {
+	defer gock.Off()
+
+	msg := &iotago.Message{Parents: tpkg.SortedRand32BytArray(1), Nonce: 1337}
+	msgID, err := msg.ID()
+	require.NoError(t, err)
+	msgIDHex := hex.EncodeToString(msgID[:])
+	serializedMsg, err := msg.Serialize(serializer.DeSeriModeNoValidation)
+	require.NoError(t, err)
+
+	mockSubmission := func(url string) {
+		gock.New(url).
+			Post(iotago.NodeAPIRouteMessages).
+			Reply(201).
+			AddHeader("Location", msgIDHex)
+
+		gock.New(url).
+			Get(fmt.Sprintf(iotago.NodeAPIRouteMessageBytes, msgIDHex)).
+			Reply(200).
+			Body(bytes.NewReader(serializedMsg))
+	}
+
+	nodes := []*iotago.NodeHTTPAPIClient{
+		iotago.NewNodeHTTPAPIClient(nodeAPIUrl),
+		iotago.NewNodeHTTPAPIClient(otherNodeAPIUrl),
+	}
+
+	t.Run("fail over", func(t *testing.T) {
+		gock.New(nodeAPIUrl).
+			Post(iotago.NodeAPIRouteMessages).
+			Reply(500).
+			JSON(&iotago.HTTPErrorResponseEnvelope{})
+		mockSubmission(otherNodeAPIUrl)
+
+		res, err := iotago.NewMultiNodeClient(nodes).SubmitMessage(context.Background(), msg)
+		require.NoError(t, err)
+		require.EqualValues(t, msg, res)
+		require.True(t, gock.IsDone())
+	})
+
+	t.Run("broadcast", func(t *testing.T) {
+		mockSubmission(nodeAPIUrl)
+		mockSubmission(otherNodeAPIUrl)
+
+		res, err := iotago.NewMultiNodeClient(nodes, iotago.WithMultiNodeClientBroadcastSubmissions(true)).
+			SubmitMessage(context.Background(), msg)
+		require.NoError(t, err)
+		require.EqualValues(t, msg, res)
+		require.True(t, gock.IsDone())
+	})
+}
+
+func TestTransactionBuilder_AddInputsViaMultiNodeQuery(t *testing.T) {
+	defer gock.Off()
+
+	addr, _ := tpkg.RandEd25519Address()
+	utxoInput, _ := tpkg.RandUTXOInput()
+	output := &iotago.SigLockedSingleOutput{Address: addr, Amount: 1_000_000}
+
+	gock.New(otherNodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteAddressEd25519Outputs, addr.String())).
+		Reply(500).
+		JSON(&iotago.HTTPErrorResponseEnvelope{})
+	mockNodeAddressOutputs(t, addr, map[*iotago.UTXOInput]iotago.Output{utxoInput: output})
+
+	multiNodeClient := iotago.NewMultiNodeClient([]*iotago.NodeHTTPAPIClient{
+		iotago.NewNodeHTTPAPIClient(otherNodeAPIUrl),
+		iotago.NewNodeHTTPAPIClient(nodeAPIUrl),
+	})
+
+	builder := iotago.NewTransactionBuilder().AddInputsViaMultiNodeQuery(context.Background(), addr, multiNodeClient, nil)
+	require.NoError(t, builder.Err())
+	require.True(t, gock.IsDone())
+
+	inputSum, err := builder.InputSum()
+	require.NoError(t, err)
+	require.EqualValues(t, 1_000_000, inputSum)
+
+	builder = iotago.NewTransactionBuilder().AddInputsViaMultiNodeQuery(context.Background(), &unknownAddress{}, multiNodeClient, nil)
+	assert.True(t, errors.Is(builder.Err(), iotago.ErrTransactionBuilderUnsupportedAddress))
+}
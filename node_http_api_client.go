@@ -9,10 +9,14 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math/rand"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/iotaledger/hive.go/serializer"
 )
@@ -30,6 +34,21 @@ var (
 	ErrHTTPUnknownError = errors.New("unknown error")
 	// ErrHTTPNotImplemented gets returned for 501 not implemented error HTTP responses.
 	ErrHTTPNotImplemented = errors.New("operation not implemented/supported/available")
+	// ErrBech32HRPMismatch gets returned if the HRP of a Bech32 address does not match the one of the node's network.
+	ErrBech32HRPMismatch = errors.New("bech32 HRP does not match the node's network")
+	// ErrNetworkIDMismatch gets returned if the network ID of a message does not match the one of the node's network.
+	ErrNetworkIDMismatch = errors.New("network ID does not match the node's network")
+	// ErrMessagePoWScoreTooLow gets returned if the PoW score of a message is below the node's minimum PoW score
+	// or the minimum PoW score set via MessageBuilder.WithMinPoWScore.
+	ErrMessagePoWScoreTooLow = errors.New("message PoW score is below the node's minimum PoW score")
+	// ErrInputAlreadySpent gets returned if an input references an output which is already spent.
+	ErrInputAlreadySpent = errors.New("input references an already spent output")
+	// ErrNodeUnavailable gets returned if a request is not made as the circuit breaker set via
+	// WithNodeHTTPAPIClientCircuitBreaker is open after consecutive failed requests to the node.
+	ErrNodeUnavailable = errors.New("node unavailable")
+	// ErrInvalidPollingParameters gets returned if the poll interval or timeout passed to
+	// SubmitMessageAndAwaitMetadata is not positive.
+	ErrInvalidPollingParameters = errors.New("poll interval and timeout must be positive")
 
 	httpCodeToErr = map[int]error{
 		http.StatusBadRequest:          ErrHTTPBadRequest,
@@ -135,11 +154,120 @@ const (
 // RequestURLHook is a function to modify the URL before sending a request.
 type RequestURLHook func(url string) string
 
+// RequestInfo describes a single HTTP request made by the NodeHTTPAPIClient.
+type RequestInfo struct {
+	// The HTTP method of the request.
+	Method string
+	// The URL of the request with any password redacted.
+	URL string
+	// The headers added to the request via WithNodeHTTPAPIClientHTTPHeader and WithNodeHTTPAPIClientBasicAuth,
+	// with the values of sensitive headers, such as the "Authorization" header, redacted.
+	Header http.Header
+	// The attempt of the request, starting at 1. Retries of a request have a higher attempt.
+	Attempt int
+	// The status code of the response or 0 if no response was received.
+	StatusCode int
+	// The duration of the request including reading and decoding the response.
+	Latency time.Duration
+	// The error which occurred during the request or nil.
+	Err error
+}
+
+// RequestObserver is a function receiving the RequestInfo of every request after it is done.
+type RequestObserver func(info RequestInfo)
+
+// the replacement of the values of sensitive headers within RequestInfo.
+const redactedHeaderValue = "[REDACTED]"
+
+// the canonical keys of headers whose values carry credentials and are therefore redacted within RequestInfo.
+var sensitiveHTTPHeaders = map[string]struct{}{
+	"Authorization":       {},
+	"Proxy-Authorization": {},
+	"Cookie":              {},
+	"X-Api-Key":           {},
+	"X-Auth-Token":        {},
+}
+
+// Tracer starts the spans of the requests made by the NodeHTTPAPIClient.
+// Tracing libraries such as OpenTelemetry can be plugged in via an adapter implementing Tracer and Span.
+type Tracer interface {
+	// Start starts a Span with the given name as a child of the span carried by ctx
+	// and returns a context carrying the new Span.
+	Start(ctx context.Context, spanName string) (context.Context, Span)
+}
+
+// Span is the traced execution of a request made by the NodeHTTPAPIClient.
+type Span interface {
+	// SetStatusCode records the status code of the response.
+	SetStatusCode(code int)
+	// RecordError records the error with which the request failed.
+	RecordError(err error)
+	// End ends the Span.
+	End()
+}
+
+// Propagator injects the tracing metadata carried by a context into the headers of an outbound request.
+type Propagator interface {
+	Inject(ctx context.Context, header http.Header)
+}
+
+// the routes as used within span names, with their parameters replaced by placeholders.
+var nodeAPIRouteSpanNames = []struct {
+	route    string
+	spanName string
+}{
+	{NodeAPIRouteMessageMetadata, "/api/v1/messages/:messageID/metadata"},
+	{NodeAPIRouteMessageBytes, "/api/v1/messages/:messageID/raw"},
+	{NodeAPIRouteMessageChildren, "/api/v1/messages/:messageID/children"},
+	{NodeAPIRouteMessageData, "/api/v1/messages/:messageID"},
+	{NodeAPIRouteMilestoneUTXOChanges, "/api/v1/milestones/:index/utxo-changes"},
+	{NodeAPIRouteMilestone, "/api/v1/milestones/:index"},
+	{NodeAPIRouteOutput, "/api/v1/outputs/:outputID"},
+	{NodeAPIRouteAddressEd25519Outputs, "/api/v1/addresses/ed25519/:address/outputs"},
+	{NodeAPIRouteAddressEd25519Balance, "/api/v1/addresses/ed25519/:address"},
+	{NodeAPIRouteAddressBech32Outputs, "/api/v1/addresses/:address/outputs"},
+	{NodeAPIRouteAddressBech32Balance, "/api/v1/addresses/:address"},
+	{NodeAPIRouteReceiptsByMigratedAtIndex, "/api/v1/receipts/:migratedAt"},
+	{NodeAPIRoutePeer, "/api/v1/peers/:peerID"},
+}
+
+// returns the name of the span of a request with the given method to the given route,
+// i.e. the method and the route with its parameters replaced by placeholders.
+func spanName(method string, route string) string {
+	if idx := strings.IndexByte(route, '?'); idx != -1 {
+		route = route[:idx]
+	}
+	segments := strings.Split(route, "/")
+	for _, r := range nodeAPIRouteSpanNames {
+		templateSegments := strings.Split(r.route, "/")
+		if len(templateSegments) != len(segments) {
+			continue
+		}
+		matches := true
+		for i, templateSegment := range templateSegments {
+			if templateSegment != "%s" && templateSegment != segments[i] {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return method + " " + r.spanName
+		}
+	}
+	return method + " " + route
+}
+
 // the default options applied to the NodeHTTPAPIClient.
 var defaultNodeAPIOptions = []NodeHTTPAPIClientOption{
 	WithNodeHTTPAPIClientHTTPClient(http.DefaultClient),
 	WithNodeHTTPAPIClientUserInfo(nil),
 	WithNodeHTTPAPIClientRequestURLHook(nil),
+	WithNodeHTTPAPIClientRetry(1, 0),
+	WithNodeHTTPAPIClientInfoCache(0),
+	WithNodeHTTPAPIClientDefaultTimeout(0),
+	WithNodeHTTPAPIClientRequestObserver(nil),
+	WithNodeHTTPAPIClientTracing(nil, nil),
+	WithNodeHTTPAPIClientCircuitBreaker(0, 0),
 }
 
 // NodeHTTPAPIClientOptions define options for the NodeHTTPAPIClient.
@@ -148,8 +276,28 @@ type NodeHTTPAPIClientOptions struct {
 	httpClient *http.Client
 	// The username and password information.
 	userInfo *url.Userinfo
+	// The headers added to every request.
+	headers http.Header
 	// The hook to modify the URL before sending a request.
 	requestURLHook RequestURLHook
+	// The maximum amount of attempts for GET requests.
+	retryMaxAttempts int
+	// The backoff before the first retry which doubles with every further attempt.
+	retryBaseBackoff time.Duration
+	// The duration for which the node info is cached.
+	infoCacheTTL time.Duration
+	// The timeout applied by ContextWithDefaultTimeout.
+	defaultTimeout time.Duration
+	// The observer of the made requests.
+	requestObserver RequestObserver
+	// The tracer starting a span per request.
+	tracer Tracer
+	// The propagator injecting tracing metadata into the request headers.
+	propagator Propagator
+	// The amount of consecutive failed requests after which the circuit breaker opens, zero if disabled.
+	breakerFailureThreshold int
+	// The duration for which the circuit breaker stays open.
+	breakerCooldown time.Duration
 }
 
 // applies the given NodeHTTPAPIClientOption.
@@ -173,6 +321,24 @@ func WithNodeHTTPAPIClientUserInfo(userInfo *url.Userinfo) NodeHTTPAPIClientOpti
 	}
 }
 
+// WithNodeHTTPAPIClientBasicAuth sets the username and password used to add basic auth "Authorization" headers
+// to the requests. It is a shorthand for WithNodeHTTPAPIClientUserInfo.
+func WithNodeHTTPAPIClientBasicAuth(username string, password string) NodeHTTPAPIClientOption {
+	return WithNodeHTTPAPIClientUserInfo(url.UserPassword(username, password))
+}
+
+// WithNodeHTTPAPIClientHTTPHeader adds the given header to every request, i.e. an "Authorization" header carrying
+// a bearer token for a node behind an API gateway. The option can be passed multiple times to add multiple headers
+// or multiple values of the same header.
+func WithNodeHTTPAPIClientHTTPHeader(key string, value string) NodeHTTPAPIClientOption {
+	return func(opts *NodeHTTPAPIClientOptions) {
+		if opts.headers == nil {
+			opts.headers = http.Header{}
+		}
+		opts.headers.Add(key, value)
+	}
+}
+
 // WithNodeHTTPAPIClientRequestURLHook is used to modify the URL before sending a request.
 func WithNodeHTTPAPIClientRequestURLHook(requestURLHook RequestURLHook) NodeHTTPAPIClientOption {
 	return func(opts *NodeHTTPAPIClientOptions) {
@@ -180,6 +346,66 @@ func WithNodeHTTPAPIClientRequestURLHook(requestURLHook RequestURLHook) NodeHTTP
 	}
 }
 
+// WithNodeHTTPAPIClientInfoCache caches the result of Info for the given duration, sharing it across calls.
+// Concurrent calls while the cache is empty or expired wait for a single request to the node.
+// Use RefreshInfo to bypass the cache. A ttl of zero disables the cache.
+func WithNodeHTTPAPIClientInfoCache(ttl time.Duration) NodeHTTPAPIClientOption {
+	return func(opts *NodeHTTPAPIClientOptions) {
+		opts.infoCacheTTL = ttl
+	}
+}
+
+// WithNodeHTTPAPIClientRetry sets the maximum amount of attempts for GET requests which fail due to network errors
+// or 5xx responses (except 503 service unavailable, which the node uses to signal that it is unhealthy).
+// Between attempts the client waits for baseBackoff, doubled with every further attempt and randomized by a jitter,
+// or until the request's context is done. Requests with any other method, i.e. POST for submitting messages
+// or adding peers, are never retried.
+func WithNodeHTTPAPIClientRetry(maxAttempts int, baseBackoff time.Duration) NodeHTTPAPIClientOption {
+	return func(opts *NodeHTTPAPIClientOptions) {
+		opts.retryMaxAttempts = maxAttempts
+		opts.retryBaseBackoff = baseBackoff
+	}
+}
+
+// WithNodeHTTPAPIClientDefaultTimeout sets the timeout of the contexts derived via ContextWithDefaultTimeout.
+// A timeout of zero disables the default timeout.
+func WithNodeHTTPAPIClientDefaultTimeout(timeout time.Duration) NodeHTTPAPIClientOption {
+	return func(opts *NodeHTTPAPIClientOptions) {
+		opts.defaultTimeout = timeout
+	}
+}
+
+// WithNodeHTTPAPIClientRequestObserver sets a RequestObserver which is called synchronously after every HTTP request,
+// including every attempt of a retried request, in order to feed logs, metrics or traces.
+func WithNodeHTTPAPIClientRequestObserver(requestObserver RequestObserver) NodeHTTPAPIClientOption {
+	return func(opts *NodeHTTPAPIClientOptions) {
+		opts.requestObserver = requestObserver
+	}
+}
+
+// WithNodeHTTPAPIClientTracing sets the Tracer starting a Span per request, named by the request's method and route,
+// e.g. "GET /api/v1/addresses/ed25519/:address/outputs", and the Propagator injecting the tracing metadata carried by
+// the request's context into its headers. Retries of a request are part of the request's Span. Both can be nil.
+func WithNodeHTTPAPIClientTracing(tracer Tracer, propagator Propagator) NodeHTTPAPIClientOption {
+	return func(opts *NodeHTTPAPIClientOptions) {
+		opts.tracer = tracer
+		opts.propagator = propagator
+	}
+}
+
+// WithNodeHTTPAPIClientCircuitBreaker sets up a circuit breaker which opens after failureThreshold consecutive
+// failed requests, that is requests failing due to network errors or 5xx responses. While the circuit breaker is open,
+// requests fail immediately with an error wrapping ErrNodeUnavailable instead of being made, so that a failing node is
+// not hammered further and callers such as TransactionBuilder.AddInputsViaNodeQuery fail fast. Once the cooldown passed,
+// a single request is let through as a probe: if it succeeds the circuit breaker closes, otherwise it opens again for
+// another cooldown. Every attempt of a retried request counts. A failureThreshold of zero disables the circuit breaker.
+func WithNodeHTTPAPIClientCircuitBreaker(failureThreshold int, cooldown time.Duration) NodeHTTPAPIClientOption {
+	return func(opts *NodeHTTPAPIClientOptions) {
+		opts.breakerFailureThreshold = failureThreshold
+		opts.breakerCooldown = cooldown
+	}
+}
+
 // NodeHTTPAPIClientOption is a function setting a NodeHTTPAPIClient option.
 type NodeHTTPAPIClientOption func(opts *NodeHTTPAPIClientOptions)
 
@@ -202,6 +428,31 @@ type NodeHTTPAPIClient struct {
 	BaseURL string
 	// holds the NodeHTTPAPIClient options.
 	opts *NodeHTTPAPIClientOptions
+
+	infoMu sync.Mutex
+	// the cached node info and the time it was fetched at.
+	info      *NodeInfoResponse
+	infoSetAt time.Time
+
+	breakerMu sync.Mutex
+	// the amount of consecutive failed requests, the time until which the circuit breaker is open
+	// and whether a probe request is in flight after the cooldown.
+	breakerFailures  int
+	breakerOpenUntil time.Time
+	breakerProbing   bool
+	// the generation of the circuit breaker's state, incremented whenever it opens or lets a probe request through.
+	// only the outcomes of requests allowed within the current generation are recorded.
+	breakerGeneration uint64
+}
+
+// ContextWithDefaultTimeout derives a context from the given parent which times out after the default timeout
+// set via WithNodeHTTPAPIClientDefaultTimeout. If the parent already has a deadline or no default timeout is set,
+// the parent is returned as is. The returned CancelFunc must be called once the calls using the context are done.
+func (api *NodeHTTPAPIClient) ContextWithDefaultTimeout(parent context.Context) (context.Context, context.CancelFunc) {
+	if _, hasDeadline := parent.Deadline(); hasDeadline || api.opts.defaultTimeout == 0 {
+		return parent, func() {}
+	}
+	return context.WithTimeout(parent, api.opts.defaultTimeout)
 }
 
 // HTTPErrorResponseEnvelope defines the error response schema for node API responses.
@@ -302,6 +553,164 @@ func (api *NodeHTTPAPIClient) Do(ctx context.Context, method string, route strin
 		url = api.opts.requestURLHook(url)
 	}
 
+	if api.opts.tracer != nil {
+		var span Span
+		ctx, span = api.opts.tracer.Start(ctx, spanName(method, route))
+		defer span.End()
+		return api.doWithRetries(ctx, method, url, data, raw, resObj, span)
+	}
+	return api.doWithRetries(ctx, method, url, data, raw, resObj, nil)
+}
+
+// executes the request, retrying it as configured via WithNodeHTTPAPIClientRetry.
+// the outcome is recorded on the given span if it is not nil.
+func (api *NodeHTTPAPIClient) doWithRetries(ctx context.Context, method string, url string, data []byte, raw bool, resObj interface{}, span Span) (*http.Response, error) {
+	maxAttempts := 1
+	if method == http.MethodGet && api.opts.retryMaxAttempts > 1 {
+		maxAttempts = api.opts.retryMaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		breakerGeneration, err := api.breakerAllow()
+		if err != nil {
+			if span != nil {
+				span.RecordError(err)
+			}
+			return nil, err
+		}
+
+		start := time.Now()
+		res, err := api.do(ctx, method, url, data, raw, resObj)
+		api.breakerRecord(ctx, breakerGeneration, res, err)
+		if api.opts.requestObserver != nil {
+			info := RequestInfo{Method: method, URL: redactedURL(url), Header: api.redactedHeader(), Attempt: attempt, Latency: time.Since(start), Err: err}
+			if res != nil {
+				info.StatusCode = res.StatusCode
+			}
+			api.opts.requestObserver(info)
+		}
+		if span != nil && res != nil {
+			span.SetStatusCode(res.StatusCode)
+		}
+		if err == nil {
+			return res, nil
+		}
+		if span != nil {
+			span.RecordError(err)
+		}
+
+		if attempt >= maxAttempts || ctx.Err() != nil || !retryable(res) {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(retryBackoff(api.opts.retryBaseBackoff, attempt)):
+		}
+	}
+}
+
+// returns the given URL with any password redacted.
+func redactedURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Redacted()
+}
+
+// returns the headers added to every request with the values of sensitive headers redacted.
+func (api *NodeHTTPAPIClient) redactedHeader() http.Header {
+	if api.opts.headers == nil && api.opts.userInfo == nil {
+		return nil
+	}
+	header := http.Header{}
+	for key, values := range api.opts.headers {
+		if _, sensitive := sensitiveHTTPHeaders[key]; sensitive {
+			values = []string{redactedHeaderValue}
+		}
+		header[key] = append([]string(nil), values...)
+	}
+	if api.opts.userInfo != nil {
+		header.Set("Authorization", redactedHeaderValue)
+	}
+	return header
+}
+
+// returns an error wrapping ErrNodeUnavailable if the circuit breaker is open, otherwise the generation
+// within which the request is allowed, which must be passed to breakerRecord.
+// once the cooldown passed, only a single probe request is allowed until its outcome was recorded.
+func (api *NodeHTTPAPIClient) breakerAllow() (uint64, error) {
+	if api.opts.breakerFailureThreshold <= 0 {
+		return 0, nil
+	}
+
+	api.breakerMu.Lock()
+	defer api.breakerMu.Unlock()
+	if api.breakerFailures < api.opts.breakerFailureThreshold {
+		return api.breakerGeneration, nil
+	}
+	if now := time.Now(); now.Before(api.breakerOpenUntil) {
+		return 0, fmt.Errorf("%w: circuit breaker for node %s is open for another %s after %d consecutive failed requests", ErrNodeUnavailable, api.BaseURL, api.breakerOpenUntil.Sub(now), api.breakerFailures)
+	}
+	if api.breakerProbing {
+		return 0, fmt.Errorf("%w: circuit breaker for node %s awaits the outcome of a probe request", ErrNodeUnavailable, api.BaseURL)
+	}
+	api.breakerProbing = true
+	api.breakerGeneration++
+	return api.breakerGeneration, nil
+}
+
+// records the outcome of a request made after breakerAllow allowed it within the given generation. Only network
+// errors and 5xx responses count as failures, while requests aborted because their context is done count neither
+// as failure nor as success. Outcomes of requests allowed before the circuit breaker opened or before the current
+// probe request was let through are stale and ignored, so that only the probe request decides whether it closes.
+func (api *NodeHTTPAPIClient) breakerRecord(ctx context.Context, generation uint64, res *http.Response, err error) {
+	if api.opts.breakerFailureThreshold <= 0 {
+		return
+	}
+
+	api.breakerMu.Lock()
+	defer api.breakerMu.Unlock()
+	if generation != api.breakerGeneration {
+		return
+	}
+	api.breakerProbing = false
+	switch {
+	case err != nil && ctx.Err() != nil:
+		return
+	case err == nil || res != nil && res.StatusCode < http.StatusInternalServerError:
+		api.breakerFailures = 0
+		return
+	}
+	api.breakerFailures++
+	if api.breakerFailures >= api.opts.breakerFailureThreshold {
+		api.breakerOpenUntil = time.Now().Add(api.opts.breakerCooldown)
+		api.breakerGeneration++
+	}
+}
+
+// tells whether a request which resulted in the given response (nil on network errors) should be retried.
+func retryable(res *http.Response) bool {
+	if res == nil {
+		return true
+	}
+	return res.StatusCode >= http.StatusInternalServerError && res.StatusCode != http.StatusServiceUnavailable
+}
+
+// returns the exponential backoff with jitter to wait before the next attempt.
+func retryBackoff(baseBackoff time.Duration, attempt int) time.Duration {
+	backoff := baseBackoff << (attempt - 1)
+	if backoff <= 0 {
+		return 0
+	}
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// executes a single request and decodes the response into resObj.
+// the response is also returned if decoding it failed.
+func (api *NodeHTTPAPIClient) do(ctx context.Context, method string, url string, data []byte, raw bool, resObj interface{}) (*http.Response, error) {
 	// construct request
 	req, err := http.NewRequestWithContext(ctx, method, url, func() io.Reader {
 		if data == nil {
@@ -318,6 +727,16 @@ func (api *NodeHTTPAPIClient) Do(ctx context.Context, method string, route strin
 		req.URL.User = api.opts.userInfo
 	}
 
+	for key, values := range api.opts.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	if api.opts.propagator != nil {
+		api.opts.propagator.Inject(ctx, req.Header)
+	}
+
 	if data != nil {
 		if !raw {
 			req.Header.Set("Content-Type", contentTypeJSON)
@@ -334,7 +753,7 @@ func (api *NodeHTTPAPIClient) Do(ctx context.Context, method string, route strin
 
 	// write response into response object
 	if err := interpretBody(res, resObj); err != nil {
-		return nil, err
+		return res, err
 	}
 	return res, nil
 }
@@ -384,7 +803,76 @@ type NodeInfoResponse struct {
 }
 
 // Info gets the info of the node.
+// The info is served from the cache if one was configured via WithNodeHTTPAPIClientInfoCache.
 func (api *NodeHTTPAPIClient) Info(ctx context.Context) (*NodeInfoResponse, error) {
+	if api.opts.infoCacheTTL <= 0 {
+		return api.fetchInfo(ctx)
+	}
+
+	api.infoMu.Lock()
+	defer api.infoMu.Unlock()
+
+	if api.info != nil && time.Since(api.infoSetAt) < api.opts.infoCacheTTL {
+		info := *api.info
+		return &info, nil
+	}
+
+	return api.refreshInfo(ctx)
+}
+
+// NetworkParameters defines the parameters of the network a node operates on.
+type NetworkParameters struct {
+	// The network ID derived from the human friendly name of the network.
+	NetworkID uint64
+	// The human friendly name of the network.
+	NetworkName string
+	// The HRP prefix used for Bech32 addresses in the network.
+	Bech32HRP NetworkPrefix
+	// The minimum pow score of the network.
+	MinPoWScore float64
+}
+
+// NetworkParameters returns the parameters of the network the node operates on, derived from its info.
+func (api *NodeHTTPAPIClient) NetworkParameters(ctx context.Context) (*NetworkParameters, error) {
+	info, err := api.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &NetworkParameters{
+		NetworkID:   NetworkIDFromString(info.NetworkID),
+		NetworkName: info.NetworkID,
+		Bech32HRP:   NetworkPrefix(info.Bech32HRP),
+		MinPoWScore: info.MinPowScore,
+	}, nil
+}
+
+// RefreshInfo gets the info of the node bypassing and updating the cache configured via WithNodeHTTPAPIClientInfoCache.
+func (api *NodeHTTPAPIClient) RefreshInfo(ctx context.Context) (*NodeInfoResponse, error) {
+	if api.opts.infoCacheTTL <= 0 {
+		return api.fetchInfo(ctx)
+	}
+
+	api.infoMu.Lock()
+	defer api.infoMu.Unlock()
+
+	return api.refreshInfo(ctx)
+}
+
+// fetches the node info and updates the cache with it. infoMu must be held by the caller.
+func (api *NodeHTTPAPIClient) refreshInfo(ctx context.Context) (*NodeInfoResponse, error) {
+	res, err := api.fetchInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	api.info = res
+	api.infoSetAt = time.Now()
+
+	info := *res
+	return &info, nil
+}
+
+func (api *NodeHTTPAPIClient) fetchInfo(ctx context.Context) (*NodeInfoResponse, error) {
 	res := &NodeInfoResponse{}
 	_, err := api.Do(ctx, http.MethodGet, NodeAPIRouteInfo, nil, res)
 	if err != nil {
@@ -453,6 +941,77 @@ func (api *NodeHTTPAPIClient) SubmitMessage(ctx context.Context, m *Message) (*M
 	return msg, nil
 }
 
+// PromoteMessage promotes the message with the given ID by submitting a new message without payload
+// which references it next to fresh tips from the node. The proof-of-work on the node's minimum PoW score
+// is configured via the given MessageBuilderPoWFunc, i.e. LocalPoW. Returns the ID of the promoting message.
+func (api *NodeHTTPAPIClient) PromoteMessage(ctx context.Context, msgID MessageID, powFunc MessageBuilderPoWFunc) (*MessageID, error) {
+	info, err := api.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query node info for promotion: %w", err)
+	}
+
+	res, err := api.Tips(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch tips for promotion: %w", err)
+	}
+
+	tips, err := res.Tips()
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch tips for promotion: %w", err)
+	}
+
+	if len(tips) > MaxParentsInAMessage-1 {
+		tips = tips[:MaxParentsInAMessage-1]
+	}
+
+	mb := NewMessageBuilder().
+		NetworkIDFromString(info.NetworkID).
+		ParentsMessageIDs(append(tips, msgID))
+
+	msg, err := powFunc(mb, info.MinPowScore).Build()
+	if err != nil {
+		return nil, fmt.Errorf("unable to build promotion message: %w", err)
+	}
+
+	return api.submitMessageAndID(ctx, msg)
+}
+
+// ReattachMessage reattaches the message with the given ID by submitting a new message carrying the same
+// payload on top of fresh tips from the node. The proof-of-work on the node's minimum PoW score is configured
+// via the given MessageBuilderPoWFunc, i.e. LocalPoW. Returns the ID of the reattached message.
+func (api *NodeHTTPAPIClient) ReattachMessage(ctx context.Context, msgID MessageID, powFunc MessageBuilderPoWFunc) (*MessageID, error) {
+	info, err := api.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query node info for reattachment: %w", err)
+	}
+
+	origin, err := api.MessageByMessageID(ctx, msgID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch message %s to reattach: %w", hex.EncodeToString(msgID[:]), err)
+	}
+
+	mb := NewMessageBuilder().
+		NetworkID(origin.NetworkID).
+		Payload(origin.Payload).
+		Tips(ctx, api)
+
+	msg, err := powFunc(mb, info.MinPowScore).Build()
+	if err != nil {
+		return nil, fmt.Errorf("unable to build reattachment message: %w", err)
+	}
+
+	return api.submitMessageAndID(ctx, msg)
+}
+
+// submits the given message and returns the ID of the message as stored by the node.
+func (api *NodeHTTPAPIClient) submitMessageAndID(ctx context.Context, msg *Message) (*MessageID, error) {
+	submitted, err := api.SubmitMessage(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	return submitted.ID()
+}
+
 // MessageIDsByIndexResponse defines the response of a GET messages REST API call.
 type MessageIDsByIndexResponse struct {
 	// The index of the messages.
@@ -481,6 +1040,107 @@ func (api *NodeHTTPAPIClient) MessageIDsByIndex(ctx context.Context, index []byt
 	return res, nil
 }
 
+// MessagesByIndexes queries the message IDs of the given indexes concurrently and returns them keyed by the
+// string conversion of their index. The optional numWorkers limits how many queries are in flight at once
+// (defaults to 4). Message IDs are deduplicated and duplicate indexes are only queried once.
+// The first failing query aborts all other queries and its error is returned.
+func (api *NodeHTTPAPIClient) MessagesByIndexes(ctx context.Context, indexes [][]byte, numWorkers ...int) (map[string]MessageIDs, error) {
+	workers := 4
+	if len(numWorkers) > 0 && numWorkers[0] > 0 {
+		workers = numWorkers[0]
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		result   = make(map[string]MessageIDs, len(indexes))
+		sem      = make(chan struct{}, workers)
+	)
+
+	for _, index := range indexes {
+		key := string(index)
+		mu.Lock()
+		_, seen := result[key]
+		if !seen {
+			result[key] = MessageIDs{}
+		}
+		mu.Unlock()
+		if seen {
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(index []byte) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			msgIDs, err := api.messageIDsByIndex(ctx, index)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("unable to query message IDs of index %x: %w", index, err)
+					cancel()
+				}
+				return
+			}
+			result[string(index)] = msgIDs
+		}(index)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// queries the deduplicated message IDs of the given index.
+func (api *NodeHTTPAPIClient) messageIDsByIndex(ctx context.Context, index []byte) (MessageIDs, error) {
+	res, err := api.MessageIDsByIndex(ctx, index)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[MessageID]struct{}, len(res.MessageIDs))
+	msgIDs := make(MessageIDs, 0, len(res.MessageIDs))
+	for _, msgIDHex := range res.MessageIDs {
+		msgIDBytes, err := hex.DecodeString(msgIDHex)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode message ID: %w", err)
+		}
+		var msgID MessageID
+		if len(msgIDBytes) != len(msgID) {
+			return nil, fmt.Errorf("invalid message ID length: expected %d bytes but got %d", len(msgID), len(msgIDBytes))
+		}
+		copy(msgID[:], msgIDBytes)
+		if _, ok := seen[msgID]; ok {
+			continue
+		}
+		seen[msgID] = struct{}{}
+		msgIDs = append(msgIDs, msgID)
+	}
+	return msgIDs, nil
+}
+
 // MessageMetadataResponse defines the response of a GET message metadata REST API call.
 type MessageMetadataResponse struct {
 	// The hex encoded message ID of the message.
@@ -516,6 +1176,130 @@ func (api *NodeHTTPAPIClient) MessageMetadataByMessageID(ctx context.Context, ms
 	return res, nil
 }
 
+// SubmitMessageAndAwaitMetadata submits the given message and polls its metadata every pollInterval
+// until the message is referenced by a milestone, which is then returned.
+// An error wrapping context.DeadlineExceeded is returned if the message isn't referenced within the given timeout.
+// An error wrapping ErrInvalidPollingParameters is returned without submitting the message if pollInterval or
+// timeout is not positive.
+func (api *NodeHTTPAPIClient) SubmitMessageAndAwaitMetadata(ctx context.Context, m *Message, pollInterval time.Duration, timeout time.Duration) (*MessageMetadataResponse, error) {
+	if pollInterval <= 0 || timeout <= 0 {
+		return nil, fmt.Errorf("%w: got a poll interval of %s and a timeout of %s", ErrInvalidPollingParameters, pollInterval, timeout)
+	}
+
+	submitted, err := api.SubmitMessage(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+
+	msgID, err := submitted.ID()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		metadata, err := api.MessageMetadataByMessageID(ctx, *msgID)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, fmt.Errorf("message %s was not referenced by a milestone in time: %w", hex.EncodeToString(msgID[:]), ctxErr)
+			}
+			return nil, err
+		}
+
+		if metadata.ReferencedByMilestoneIndex != nil {
+			return metadata, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("message %s was not referenced by a milestone in time: %w", hex.EncodeToString(msgID[:]), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// ValidateMessage checks whether the given message would be accepted by the node without submitting it.
+// As the node API offers no endpoint to dry-run a submission, the validation is entirely performed locally:
+// the message is syntactically validated, its network ID and, unless the nonce is zero (in which case the node
+// performs the PoW on submission), its PoW score are checked against the node's parameters and a transaction payload
+// is semantically validated, including the dust protection rules, against the input UTXOs queried from the node.
+func (api *NodeHTTPAPIClient) ValidateMessage(ctx context.Context, m *Message) error {
+	if _, err := m.Serialize(serializer.DeSeriModePerformValidation); err != nil {
+		return fmt.Errorf("message is syntactically invalid: %w", err)
+	}
+
+	info, err := api.Info(ctx)
+	if err != nil {
+		return err
+	}
+
+	if networkID := NetworkIDFromString(info.NetworkID); m.NetworkID != networkID {
+		return fmt.Errorf("%w: message has network ID %d but node is on %d (%s)", ErrNetworkIDMismatch, m.NetworkID, networkID, info.NetworkID)
+	}
+
+	if m.Nonce != 0 {
+		powScore, err := m.PoWScore()
+		if err != nil {
+			return fmt.Errorf("unable to compute PoW score of message: %w", err)
+		}
+		if powScore < info.MinPowScore {
+			return fmt.Errorf("%w: %f < %f", ErrMessagePoWScoreTooLow, powScore, info.MinPowScore)
+		}
+	}
+
+	tx, ok := m.Payload.(*Transaction)
+	if !ok {
+		return nil
+	}
+
+	utxos, err := api.inputUTXOs(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	dustValidation := NewDustSemanticValidation(DustAllowanceDivisor, MaxDustOutputsOnAddress, nodeDustAllowanceFunc(ctx, api))
+	if err := tx.SemanticallyValidate(utxos, dustValidation); err != nil {
+		return fmt.Errorf("transaction is semantically invalid: %w", err)
+	}
+
+	return nil
+}
+
+// queries the unspent outputs referenced by the inputs of the given transaction.
+func (api *NodeHTTPAPIClient) inputUTXOs(ctx context.Context, tx *Transaction) (InputToOutputMapping, error) {
+	txEssence, ok := tx.Essence.(*TransactionEssence)
+	if !ok {
+		return nil, fmt.Errorf("%w: transaction essence is not *TransactionEssence", ErrInvalidTransactionEssence)
+	}
+
+	utxos := InputToOutputMapping{}
+	for _, input := range txEssence.Inputs {
+		utxoInput, ok := input.(*UTXOInput)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T", ErrUnknownInputType, input)
+		}
+		utxoID := utxoInput.ID()
+		res, err := api.OutputByID(ctx, utxoID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to query UTXO %s: %w", utxoID.ToHex(), err)
+		}
+		if res.Spent {
+			return nil, fmt.Errorf("%w: UTXO %s", ErrInputAlreadySpent, utxoID.ToHex())
+		}
+		output, err := res.Output()
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode UTXO %s: %w", utxoID.ToHex(), err)
+		}
+		utxos[utxoID] = output
+	}
+	return utxos, nil
+}
+
 // MessageJSONByMessageID get a message by its message ID from the node (json).
 func (api *NodeHTTPAPIClient) MessageJSONByMessageID(ctx context.Context, msgID MessageID) (*Message, error) {
 	query := fmt.Sprintf(NodeAPIRouteMessageData, hex.EncodeToString(msgID[:]))
@@ -581,6 +1365,9 @@ type NodeOutputResponse struct {
 	OutputIndex uint16 `json:"outputIndex"`
 	// Whether this output is spent.
 	Spent bool `json:"isSpent"`
+	// The index of the milestone which spent this output, zero if the output is unspent
+	// or the node does not expose it.
+	MilestoneIndexSpent uint32 `json:"milestoneIndexSpent,omitempty"`
 	// The ledger index at which this output was available at.
 	LedgerIndex uint64 `json:"ledgerIndex"`
 	// The output in its serialized form.
@@ -600,19 +1387,10 @@ func (nor *NodeOutputResponse) TxID() (*TransactionID, error) {
 
 // Output deserializes the RawOutput to an Output.
 func (nor *NodeOutputResponse) Output() (Output, error) {
-	jsonSeri, err := DeserializeObjectFromJSON(nor.RawOutput, jsonOutputSelector)
-	if err != nil {
-		return nil, err
+	if nor.RawOutput == nil {
+		return nil, fmt.Errorf("%w: output response holds no output", ErrUnknownOutputType)
 	}
-	seri, err := jsonSeri.ToSerializable()
-	if err != nil {
-		return nil, err
-	}
-	output, isOutput := seri.(Output)
-	if !isOutput {
-		return nil, ErrUnknownOutputType
-	}
-	return output, nil
+	return OutputFromRESTJSON(*nor.RawOutput)
 }
 
 // OutputByID gets an outputs by its ID from the node.
@@ -682,6 +1460,12 @@ type AddressOutputsResponse struct {
 	LedgerIndex uint64 `json:"ledgerIndex"`
 }
 
+// MaxResultsReached tells whether the node returned as many output IDs as it maximally returns per query,
+// in which case the response is likely to not contain all outputs residing on the address.
+func (res *AddressOutputsResponse) MaxResultsReached() bool {
+	return res.MaxResults != 0 && res.Count >= res.MaxResults
+}
+
 // OutputIDsByBech32Address gets output IDs of outputs residing on the given Bech32 address.
 // Per default only unspent outputs IDs are returned. Set includeSpentOutputs to true to also return spent output IDs.
 func (api *NodeHTTPAPIClient) OutputIDsByBech32Address(ctx context.Context, bech32Addr string, includeSpentOutputs bool) (*AddressOutputsResponse, error) {
@@ -700,14 +1484,30 @@ func (api *NodeHTTPAPIClient) OutputIDsByBech32Address(ctx context.Context, bech
 }
 
 // OutputsByBech32Address gets the outputs residing on the given Bech32 address.
+// The address is parsed and its HRP is checked against the one of the node's network before the request
+// is dispatched to the endpoint of the underlying address type. ErrBech32HRPMismatch is returned if the HRPs differ.
 // Per default only unspent outputs are returned. Set includeSpentOutputs to true to also return spent outputs.
 func (api *NodeHTTPAPIClient) OutputsByBech32Address(ctx context.Context, bech32Addr string, includeSpentOutputs bool) (*AddressOutputsResponse, map[*UTXOInput]Output, error) {
-	res, err := api.OutputIDsByBech32Address(ctx, bech32Addr, includeSpentOutputs)
+	hrp, addr, err := ParseBech32(bech32Addr)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, fmt.Errorf("unable to parse bech32 address %s: %w", bech32Addr, err)
 	}
 
-	return api.outputIDsToOutputs(ctx, res)
+	info, err := api.Info(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to query node info for HRP validation: %w", err)
+	}
+
+	if string(hrp) != info.Bech32HRP {
+		return nil, nil, fmt.Errorf("%w: address has HRP '%s' but the node's network uses '%s'", ErrBech32HRPMismatch, hrp, info.Bech32HRP)
+	}
+
+	switch a := addr.(type) {
+	case *Ed25519Address:
+		return api.OutputsByEd25519Address(ctx, a, includeSpentOutputs)
+	default:
+		return nil, nil, fmt.Errorf("%w: unsupported address type %T", ErrUnknownAddrType, addr)
+	}
 }
 
 // OutputIDsByEd25519Address gets output IDs of outputs residing on the given Ed25519Address.
@@ -738,9 +1538,70 @@ func (api *NodeHTTPAPIClient) OutputsByEd25519Address(ctx context.Context, addr
 	return api.outputIDsToOutputs(ctx, res)
 }
 
+// OutputWithMetadata is an output together with its spend status as returned by the node.
+type OutputWithMetadata struct {
+	// The output.
+	Output Output
+	// Whether the output is spent.
+	Spent bool
+	// The index of the milestone which spent the output, zero if the output is unspent or the node does not expose it.
+	MilestoneIndexSpent uint32
+	// The ledger index of the node at the time the output was queried.
+	LedgerIndex uint64
+}
+
+// OutputsWithMetadataByEd25519Address works like OutputsByEd25519Address but additionally returns the spend status
+// of every output, so that spent outputs can be told apart if includeSpentOutputs is true.
+func (api *NodeHTTPAPIClient) OutputsWithMetadataByEd25519Address(ctx context.Context, addr *Ed25519Address, includeSpentOutputs bool) (*AddressOutputsResponse, map[*UTXOInput]*OutputWithMetadata, error) {
+	res, err := api.OutputIDsByEd25519Address(ctx, addr, includeSpentOutputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return api.outputIDsToOutputsWithMetadata(ctx, res)
+}
+
+// UnspentInputsForAddress returns the unspent outputs of the given address as ToBeSignedUTXOInputs with their address
+// set, sorted by their output IDs. Unlike TransactionBuilder.AddInputsViaNodeQuery, the inputs are not added to
+// any builder, so that they can be inspected first and then selectively added via TransactionBuilder.AddInputs.
+// Only Ed25519Address is supported. An error is returned if the node returned its maximum amount of outputs,
+// as the result is likely incomplete then.
+func (api *NodeHTTPAPIClient) UnspentInputsForAddress(ctx context.Context, addr Address) ([]*ToBeSignedUTXOInput, error) {
+	unspentOutputs, err := unspentOutputsByAddress(ctx, addr, api)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make([]*ToBeSignedUTXOInput, 0, len(unspentOutputs))
+	for utxoInput, output := range unspentOutputs {
+		inputs = append(inputs, &ToBeSignedUTXOInput{Address: addr, Input: utxoInput, Output: output})
+	}
+	sort.Slice(inputs, func(i, j int) bool {
+		iID, jID := inputs[i].Input.ID(), inputs[j].Input.ID()
+		return bytes.Compare(iID[:], jID[:]) < 0
+	})
+
+	return inputs, nil
+}
+
 // queries the actual outputs given an AddressOutputsResponse.
 func (api *NodeHTTPAPIClient) outputIDsToOutputs(ctx context.Context, res *AddressOutputsResponse) (*AddressOutputsResponse, map[*UTXOInput]Output, error) {
-	outputs := make(map[*UTXOInput]Output)
+	res, outputsWithMetadata, err := api.outputIDsToOutputsWithMetadata(ctx, res)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	outputs := make(map[*UTXOInput]Output, len(outputsWithMetadata))
+	for utxoInput, outputWithMetadata := range outputsWithMetadata {
+		outputs[utxoInput] = outputWithMetadata.Output
+	}
+
+	return res, outputs, nil
+}
+
+// queries the actual outputs and their spend status given an AddressOutputsResponse.
+func (api *NodeHTTPAPIClient) outputIDsToOutputsWithMetadata(ctx context.Context, res *AddressOutputsResponse) (*AddressOutputsResponse, map[*UTXOInput]*OutputWithMetadata, error) {
+	outputs := make(map[*UTXOInput]*OutputWithMetadata)
 	for _, outputIDHex := range res.OutputIDs {
 		utxoInput, err := outputIDHex.AsUTXOInput()
 		if err != nil {
@@ -756,7 +1617,12 @@ func (api *NodeHTTPAPIClient) outputIDsToOutputs(ctx context.Context, res *Addre
 		if err != nil {
 			return nil, nil, err
 		}
-		outputs[utxoInput] = output
+		outputs[utxoInput] = &OutputWithMetadata{
+			Output:              output,
+			Spent:               outputRes.Spent,
+			MilestoneIndexSpent: outputRes.MilestoneIndexSpent,
+			LedgerIndex:         outputRes.LedgerIndex,
+		}
 	}
 
 	return res, outputs, nil
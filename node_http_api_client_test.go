@@ -5,16 +5,22 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/iotaledger/hive.go/serializer"
+	"io"
 	"math/rand"
+	"net/http"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	"gopkg.in/h2non/gock.v1"
 
+	"github.com/iotaledger/iota.go/v2/ed25519"
 	"github.com/iotaledger/iota.go/v2/tpkg"
 
 	iotago "github.com/iotaledger/iota.go/v2"
@@ -73,6 +79,488 @@ func TestNodeAPI_Info(t *testing.T) {
 	require.EqualValues(t, originInfo, info)
 }
 
+func TestNodeAPI_RequestObserver(t *testing.T) {
+	defer gock.Off()
+
+	originInfo := &iotago.NodeInfoResponse{Name: "HORNET", Bech32HRP: "atoi"}
+
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteInfo).
+		Reply(500).
+		JSON(&iotago.HTTPErrorResponseEnvelope{})
+
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteInfo).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: originInfo})
+
+	var infos []iotago.RequestInfo
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl,
+		iotago.WithNodeHTTPAPIClientRetry(2, time.Millisecond),
+		iotago.WithNodeHTTPAPIClientRequestObserver(func(info iotago.RequestInfo) {
+			infos = append(infos, info)
+		}),
+	)
+	_, err := nodeAPI.Info(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, infos, 2)
+	for i, info := range infos {
+		require.Equal(t, http.MethodGet, info.Method)
+		require.Equal(t, nodeAPIUrl+iotago.NodeAPIRouteInfo, info.URL)
+		require.Equal(t, i+1, info.Attempt)
+		require.Greater(t, int64(info.Latency), int64(0))
+	}
+	require.Equal(t, 500, infos[0].StatusCode)
+	require.True(t, errors.Is(infos[0].Err, iotago.ErrHTTPInternalServerError))
+	require.Equal(t, 200, infos[1].StatusCode)
+	require.NoError(t, infos[1].Err)
+}
+
+func TestNodeAPI_HTTPHeaders(t *testing.T) {
+	defer gock.Off()
+
+	msg := &iotago.Message{Parents: tpkg.SortedRand32BytArray(1), Nonce: 1337}
+	msgID, err := msg.ID()
+	require.NoError(t, err)
+
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteInfo).
+		MatchHeader("Authorization", "^Bearer secret-token$").
+		MatchHeader("X-Gateway-Route", "^iota$").
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.NodeInfoResponse{Name: "HORNET"}})
+
+	gock.New(nodeAPIUrl).
+		Post(iotago.NodeAPIRouteMessages).
+		MatchHeader("Authorization", "^Bearer secret-token$").
+		MatchHeader("X-Gateway-Route", "^iota$").
+		Reply(201).
+		AddHeader("Location", hex.EncodeToString(msgID[:]))
+
+	serializedMsg, err := msg.Serialize(serializer.DeSeriModeNoValidation)
+	require.NoError(t, err)
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteMessageBytes, hex.EncodeToString(msgID[:]))).
+		MatchHeader("Authorization", "^Bearer secret-token$").
+		MatchHeader("X-Gateway-Route", "^iota$").
+		Reply(200).
+		Body(bytes.NewReader(serializedMsg))
+
+	var infos []iotago.RequestInfo
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl,
+		iotago.WithNodeHTTPAPIClientHTTPHeader("Authorization", "Bearer secret-token"),
+		iotago.WithNodeHTTPAPIClientHTTPHeader("X-Gateway-Route", "iota"),
+		iotago.WithNodeHTTPAPIClientRequestObserver(func(info iotago.RequestInfo) {
+			infos = append(infos, info)
+		}),
+	)
+
+	_, err = nodeAPI.Info(context.Background())
+	require.NoError(t, err)
+	_, err = nodeAPI.SubmitMessage(context.Background(), msg)
+	require.NoError(t, err)
+	require.True(t, gock.IsDone())
+
+	require.Len(t, infos, 3)
+	for _, info := range infos {
+		require.Equal(t, http.Header{
+			"Authorization":   []string{"[REDACTED]"},
+			"X-Gateway-Route": []string{"iota"},
+		}, info.Header)
+	}
+
+	// basic auth
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteInfo).
+		MatchHeader("Authorization", "^Basic dXNlcjpwYXNz$").
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.NodeInfoResponse{Name: "HORNET"}})
+
+	infos = nil
+	nodeAPI = iotago.NewNodeHTTPAPIClient(nodeAPIUrl,
+		iotago.WithNodeHTTPAPIClientBasicAuth("user", "pass"),
+		iotago.WithNodeHTTPAPIClientRequestObserver(func(info iotago.RequestInfo) {
+			infos = append(infos, info)
+		}),
+	)
+	_, err = nodeAPI.Info(context.Background())
+	require.NoError(t, err)
+	require.True(t, gock.IsDone())
+	require.Len(t, infos, 1)
+	require.Equal(t, http.Header{"Authorization": []string{"[REDACTED]"}}, infos[0].Header)
+}
+
+func TestNodeAPI_CircuitBreaker(t *testing.T) {
+	defer gock.Off()
+
+	const cooldown = 50 * time.Millisecond
+
+	mockInfo := func(status int) {
+		gock.New(nodeAPIUrl).
+			Get(iotago.NodeAPIRouteInfo).
+			Reply(status).
+			JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.NodeInfoResponse{Name: "HORNET"}})
+	}
+
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl, iotago.WithNodeHTTPAPIClientCircuitBreaker(2, cooldown))
+
+	// client errors do not count as failures
+	mockInfo(500)
+	mockInfo(404)
+	mockInfo(500)
+	for i := 0; i < 3; i++ {
+		_, err := nodeAPI.Info(context.Background())
+		require.Error(t, err)
+		require.False(t, errors.Is(err, iotago.ErrNodeUnavailable))
+	}
+
+	// opens after two consecutive failures
+	mockInfo(500)
+	_, err := nodeAPI.Info(context.Background())
+	require.True(t, errors.Is(err, iotago.ErrHTTPInternalServerError))
+	require.True(t, gock.IsDone())
+
+	addr, _ := tpkg.RandEd25519Address()
+	builder := iotago.NewTransactionBuilder().AddInputsViaNodeQuery(context.Background(), addr, nodeAPI, nil)
+	require.True(t, errors.Is(builder.Err(), iotago.ErrNodeUnavailable))
+
+	// a failing probe after the cooldown opens it again
+	time.Sleep(cooldown)
+	mockInfo(500)
+	_, err = nodeAPI.Info(context.Background())
+	require.True(t, errors.Is(err, iotago.ErrHTTPInternalServerError))
+	_, err = nodeAPI.Info(context.Background())
+	require.True(t, errors.Is(err, iotago.ErrNodeUnavailable))
+
+	// a succeeding probe closes it
+	time.Sleep(cooldown)
+	mockInfo(200)
+	mockInfo(200)
+	for i := 0; i < 2; i++ {
+		_, err = nodeAPI.Info(context.Background())
+		require.NoError(t, err)
+	}
+	require.True(t, gock.IsDone())
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNodeAPI_CircuitBreaker_StaleRequests(t *testing.T) {
+	const cooldown = 50 * time.Millisecond
+
+	// requests to the tips and health routes block until they are released with a status code
+	// or their context is done, requests to any other route fail
+	entered := make(chan string)
+	release := map[string]chan int{iotago.NodeAPIRouteTips: make(chan int), iotago.NodeAPIRouteHealth: make(chan int)}
+	httpClient := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		status := http.StatusInternalServerError
+		if releaseCh, blocks := release[req.URL.Path]; blocks {
+			entered <- req.URL.Path
+			select {
+			case status = <-releaseCh:
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			}
+		}
+		return &http.Response{StatusCode: status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("{}")), Request: req}, nil
+	})}
+	newNodeAPI := func() *iotago.NodeHTTPAPIClient {
+		return iotago.NewNodeHTTPAPIClient(nodeAPIUrl, iotago.WithNodeHTTPAPIClientHTTPClient(httpClient), iotago.WithNodeHTTPAPIClientCircuitBreaker(1, cooldown))
+	}
+
+	// a request succeeding after the circuit breaker opened does not close it
+	nodeAPI := newNodeAPI()
+	staleErr := make(chan error)
+	go func() {
+		_, err := nodeAPI.Tips(context.Background())
+		staleErr <- err
+	}()
+	<-entered
+
+	_, err := nodeAPI.Info(context.Background())
+	require.True(t, errors.Is(err, iotago.ErrHTTPInternalServerError))
+
+	release[iotago.NodeAPIRouteTips] <- http.StatusOK
+	require.NoError(t, <-staleErr)
+	_, err = nodeAPI.Info(context.Background())
+	require.True(t, errors.Is(err, iotago.ErrNodeUnavailable))
+
+	// a request aborted while the probe is in flight does not free the probe slot
+	nodeAPI = newNodeAPI()
+	staleCtx, abortStale := context.WithCancel(context.Background())
+	go func() {
+		_, err := nodeAPI.Tips(staleCtx)
+		staleErr <- err
+	}()
+	<-entered
+
+	_, err = nodeAPI.Info(context.Background())
+	require.True(t, errors.Is(err, iotago.ErrHTTPInternalServerError))
+
+	time.Sleep(cooldown)
+	probeErr := make(chan error)
+	go func() {
+		_, err := nodeAPI.Health(context.Background())
+		probeErr <- err
+	}()
+	<-entered
+
+	abortStale()
+	require.True(t, errors.Is(<-staleErr, context.Canceled))
+	_, err = nodeAPI.Info(context.Background())
+	require.True(t, errors.Is(err, iotago.ErrNodeUnavailable))
+
+	// only the outcome of the probe closes it
+	release[iotago.NodeAPIRouteHealth] <- http.StatusOK
+	require.NoError(t, <-probeErr)
+	_, err = nodeAPI.Info(context.Background())
+	require.True(t, errors.Is(err, iotago.ErrHTTPInternalServerError))
+}
+
+type traceIDKey struct{}
+
+type fakeSpan struct {
+	name       string
+	statusCode int
+	errs       []error
+	ended      bool
+}
+
+func (s *fakeSpan) SetStatusCode(code int) { s.statusCode = code }
+func (s *fakeSpan) RecordError(err error)  { s.errs = append(s.errs, err) }
+func (s *fakeSpan) End()                   { s.ended = true }
+
+type fakeTracer struct {
+	spans []*fakeSpan
+}
+
+func (tr *fakeTracer) Start(ctx context.Context, spanName string) (context.Context, iotago.Span) {
+	span := &fakeSpan{name: spanName}
+	tr.spans = append(tr.spans, span)
+	return context.WithValue(ctx, traceIDKey{}, fmt.Sprintf("trace-%d", len(tr.spans))), span
+}
+
+type fakePropagator struct{}
+
+func (fakePropagator) Inject(ctx context.Context, header http.Header) {
+	if traceID, ok := ctx.Value(traceIDKey{}).(string); ok {
+		header.Set("traceparent", traceID)
+	}
+}
+
+func TestNodeAPI_Tracing(t *testing.T) {
+	defer gock.Off()
+
+	addr, _ := tpkg.RandEd25519Address()
+
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteAddressEd25519Outputs, addr.String())).
+		MatchHeader("traceparent", "trace-1").
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.AddressOutputsResponse{}})
+
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteInfo).
+		MatchHeader("traceparent", "trace-2").
+		Reply(500).
+		JSON(&iotago.HTTPErrorResponseEnvelope{})
+
+	tracer := &fakeTracer{}
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl, iotago.WithNodeHTTPAPIClientTracing(tracer, fakePropagator{}))
+
+	_, err := nodeAPI.OutputIDsByEd25519Address(context.Background(), addr, true)
+	require.NoError(t, err)
+
+	_, err = nodeAPI.Info(context.Background())
+	require.True(t, errors.Is(err, iotago.ErrHTTPInternalServerError))
+	require.True(t, gock.IsDone())
+
+	require.Len(t, tracer.spans, 2)
+	require.Equal(t, "GET /api/v1/addresses/ed25519/:address/outputs", tracer.spans[0].name)
+	require.Equal(t, 200, tracer.spans[0].statusCode)
+	require.Empty(t, tracer.spans[0].errs)
+	require.True(t, tracer.spans[0].ended)
+
+	require.Equal(t, "GET "+iotago.NodeAPIRouteInfo, tracer.spans[1].name)
+	require.Equal(t, 500, tracer.spans[1].statusCode)
+	require.Len(t, tracer.spans[1].errs, 1)
+	require.True(t, tracer.spans[1].ended)
+}
+
+func TestNodeAPI_ContextWithDefaultTimeout(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteHealth).
+		Reply(200).
+		Delay(100 * time.Millisecond)
+
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl, iotago.WithNodeHTTPAPIClientDefaultTimeout(10*time.Millisecond))
+	ctx, cancel := nodeAPI.ContextWithDefaultTimeout(context.Background())
+	defer cancel()
+	_, hasDeadline := ctx.Deadline()
+	require.True(t, hasDeadline)
+	_, err := nodeAPI.Health(ctx)
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	// a deadline of the parent is respected as is
+	parentDeadline := time.Now().Add(time.Hour)
+	parent, parentCancel := context.WithDeadline(context.Background(), parentDeadline)
+	defer parentCancel()
+	ctx, cancel = nodeAPI.ContextWithDefaultTimeout(parent)
+	defer cancel()
+	deadline, _ := ctx.Deadline()
+	require.Equal(t, parentDeadline, deadline)
+
+	// without a default timeout the parent is returned
+	ctx, cancel = iotago.NewNodeHTTPAPIClient(nodeAPIUrl).ContextWithDefaultTimeout(context.Background())
+	defer cancel()
+	_, hasDeadline = ctx.Deadline()
+	require.False(t, hasDeadline)
+}
+
+func TestNodeAPI_Retry(t *testing.T) {
+	defer gock.Off()
+
+	originInfo := &iotago.NodeInfoResponse{Name: "HORNET", Bech32HRP: "atoi"}
+	errRes := &iotago.HTTPErrorResponseEnvelope{}
+
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteInfo).
+		Times(2).
+		Reply(500).
+		JSON(errRes)
+
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteInfo).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: originInfo})
+
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl, iotago.WithNodeHTTPAPIClientRetry(3, time.Millisecond))
+	info, err := nodeAPI.Info(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, originInfo, info)
+
+	// gives up after the max attempts
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteInfo).
+		Times(3).
+		Reply(500).
+		JSON(errRes)
+
+	_, err = nodeAPI.Info(context.Background())
+	require.True(t, errors.Is(err, iotago.ErrHTTPInternalServerError))
+	require.True(t, gock.IsDone())
+
+	// client errors are not retried
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteInfo).
+		Reply(404).
+		JSON(errRes)
+
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteInfo).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: originInfo})
+
+	_, err = nodeAPI.Info(context.Background())
+	require.True(t, errors.Is(err, iotago.ErrHTTPNotFound))
+	require.True(t, gock.IsPending())
+	gock.Flush()
+
+	// POST requests are not retried
+	gock.New(nodeAPIUrl).
+		Post(iotago.NodeAPIRouteMessages).
+		Reply(500).
+		JSON(errRes)
+
+	gock.New(nodeAPIUrl).
+		Post(iotago.NodeAPIRouteMessages).
+		Reply(200).
+		AddHeader("Location", hex.EncodeToString(tpkg.RandBytes(32)))
+
+	_, err = nodeAPI.SubmitMessage(context.Background(), &iotago.Message{Parents: tpkg.SortedRand32BytArray(1)})
+	require.True(t, errors.Is(err, iotago.ErrHTTPInternalServerError))
+	require.True(t, gock.IsPending())
+}
+
+func TestNodeAPI_NetworkParameters(t *testing.T) {
+	defer gock.Off()
+
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteInfo).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.NodeInfoResponse{
+			NetworkID:   "alphanet@1",
+			Bech32HRP:   "atoi",
+			MinPowScore: 4000.0,
+		}})
+
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+	params, err := nodeAPI.NetworkParameters(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, &iotago.NetworkParameters{
+		NetworkID:   iotago.NetworkIDFromString("alphanet@1"),
+		NetworkName: "alphanet@1",
+		Bech32HRP:   iotago.PrefixTestnet,
+		MinPoWScore: 4000.0,
+	}, params)
+}
+
+func TestNodeAPI_InfoCache(t *testing.T) {
+	defer gock.Off()
+
+	mockInfo := func(hrp string) {
+		gock.New(nodeAPIUrl).
+			Get(iotago.NodeAPIRouteInfo).
+			Reply(200).
+			JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.NodeInfoResponse{Bech32HRP: hrp}})
+	}
+
+	// concurrent callers share a single request
+	mockInfo("atoi")
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl, iotago.WithNodeHTTPAPIClientInfoCache(time.Minute))
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			info, err := nodeAPI.Info(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, "atoi", info.Bech32HRP)
+		}()
+	}
+	wg.Wait()
+	require.True(t, gock.IsDone())
+
+	// refresh bypasses the cache and updates it
+	mockInfo("iota")
+	info, err := nodeAPI.RefreshInfo(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "iota", info.Bech32HRP)
+
+	info, err = nodeAPI.Info(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "iota", info.Bech32HRP)
+
+	// expired entries are fetched again
+	nodeAPI = iotago.NewNodeHTTPAPIClient(nodeAPIUrl, iotago.WithNodeHTTPAPIClientInfoCache(time.Nanosecond))
+	mockInfo("atoi")
+	mockInfo("iota")
+	info, err = nodeAPI.Info(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "atoi", info.Bech32HRP)
+	time.Sleep(time.Millisecond)
+	info, err = nodeAPI.Info(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "iota", info.Bech32HRP)
+}
+
 func TestNodeAPI_Tips(t *testing.T) {
 	defer gock.Off()
 
@@ -135,6 +623,215 @@ func TestNodeAPI_SubmitMessage(t *testing.T) {
 	require.EqualValues(t, completeMsg, resp)
 }
 
+// mocks the node API calls needed to build and submit a new message and returns the message the node stores.
+func mockNodeMessageSubmission(t *testing.T) *iotago.Message {
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteInfo).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.NodeInfoResponse{NetworkID: "alphanet@1", MinPowScore: 1}})
+
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteTips).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.NodeTipsResponse{TipsHex: []string{
+			hex.EncodeToString(tpkg.RandBytes(32)), hex.EncodeToString(tpkg.RandBytes(32)),
+		}}})
+
+	storedMsg := &iotago.Message{
+		NetworkID: iotago.NetworkIDFromString("alphanet@1"),
+		Parents:   tpkg.SortedRand32BytArray(2),
+		Nonce:     1337,
+	}
+	storedMsgID, err := storedMsg.ID()
+	require.NoError(t, err)
+	storedMsgIDHex := hex.EncodeToString(storedMsgID[:])
+
+	serializedStoredMsg, err := storedMsg.Serialize(serializer.DeSeriModeNoValidation)
+	require.NoError(t, err)
+
+	gock.New(nodeAPIUrl).
+		Post(iotago.NodeAPIRouteMessages).
+		Reply(200).
+		AddHeader("Location", storedMsgIDHex)
+
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteMessageBytes, storedMsgIDHex)).
+		Reply(200).
+		Body(bytes.NewReader(serializedStoredMsg))
+
+	return storedMsg
+}
+
+func TestNodeAPI_PromoteMessage(t *testing.T) {
+	defer gock.Off()
+
+	storedMsg := mockNodeMessageSubmission(t)
+
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+	msgID, err := nodeAPI.PromoteMessage(context.Background(), tpkg.Rand32ByteArray(), iotago.LocalPoW(context.Background(), 1))
+	require.NoError(t, err)
+	require.EqualValues(t, storedMsg.MustID(), *msgID)
+}
+
+func TestNodeAPI_ReattachMessage(t *testing.T) {
+	defer gock.Off()
+
+	originMsg := &iotago.Message{
+		NetworkID: iotago.NetworkIDFromString("alphanet@1"),
+		Parents:   tpkg.SortedRand32BytArray(1),
+		Payload:   &iotago.Indexation{Index: []byte("index"), Data: []byte("data")},
+		Nonce:     42,
+	}
+	originMsgID, err := originMsg.ID()
+	require.NoError(t, err)
+	serializedOriginMsg, err := originMsg.Serialize(serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteMessageBytes, hex.EncodeToString(originMsgID[:]))).
+		Reply(200).
+		Body(bytes.NewReader(serializedOriginMsg))
+
+	storedMsg := mockNodeMessageSubmission(t)
+
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+	msgID, err := nodeAPI.ReattachMessage(context.Background(), *originMsgID, iotago.LocalPoW(context.Background(), 1))
+	require.NoError(t, err)
+	require.EqualValues(t, storedMsg.MustID(), *msgID)
+}
+
+func TestNodeAPI_SubmitMessageAndAwaitMetadata(t *testing.T) {
+	defer gock.Off()
+
+	msg := &iotago.Message{Parents: tpkg.SortedRand32BytArray(1), Nonce: 1337}
+	msgID, err := msg.ID()
+	require.NoError(t, err)
+	msgIDHex := hex.EncodeToString(msgID[:])
+	serializedMsg, err := msg.Serialize(serializer.DeSeriModeNoValidation)
+	require.NoError(t, err)
+
+	mockSubmission := func() {
+		gock.New(nodeAPIUrl).
+			Post(iotago.NodeAPIRouteMessages).
+			Reply(200).
+			AddHeader("Location", msgIDHex)
+		gock.New(nodeAPIUrl).
+			Get(fmt.Sprintf(iotago.NodeAPIRouteMessageBytes, msgIDHex)).
+			Reply(200).
+			Body(bytes.NewReader(serializedMsg))
+	}
+
+	mockSubmission()
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteMessageMetadata, msgIDHex)).
+		Times(2).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.MessageMetadataResponse{MessageID: msgIDHex}})
+
+	referencedBy := uint32(1337)
+	referencedMetadata := &iotago.MessageMetadataResponse{MessageID: msgIDHex, Solid: true, ReferencedByMilestoneIndex: &referencedBy}
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteMessageMetadata, msgIDHex)).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: referencedMetadata})
+
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+	metadata, err := nodeAPI.SubmitMessageAndAwaitMetadata(context.Background(), msg, time.Millisecond, time.Second)
+	require.NoError(t, err)
+	require.EqualValues(t, referencedMetadata, metadata)
+
+	// never referenced
+	mockSubmission()
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteMessageMetadata, msgIDHex)).
+		Persist().
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.MessageMetadataResponse{MessageID: msgIDHex}})
+
+	_, err = nodeAPI.SubmitMessageAndAwaitMetadata(context.Background(), msg, time.Millisecond, 20*time.Millisecond)
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestNodeAPI_SubmitMessageAndAwaitMetadata_InvalidPollingParameters(t *testing.T) {
+	defer gock.Off()
+
+	// intercepts all requests, so that a submission would be recorded as an unmatched request
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteHealth).
+		Reply(200)
+
+	msg := &iotago.Message{Parents: tpkg.SortedRand32BytArray(1), Nonce: 1337}
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+	for _, params := range [][2]time.Duration{{0, time.Second}, {-time.Millisecond, time.Second}, {time.Millisecond, 0}, {time.Millisecond, -time.Second}} {
+		_, err := nodeAPI.SubmitMessageAndAwaitMetadata(context.Background(), msg, params[0], params[1])
+		require.True(t, errors.Is(err, iotago.ErrInvalidPollingParameters))
+	}
+	require.False(t, gock.HasUnmatchedRequest())
+}
+
+func TestNodeAPI_ValidateMessage(t *testing.T) {
+	defer gock.Off()
+
+	const networkID = "alphanet@1"
+
+	identity := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identity.Public().(ed25519.PublicKey))
+	outputAddr, _ := tpkg.RandEd25519Address()
+	inputUTXO, _ := tpkg.RandUTXOInput()
+	input := &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 1_000_000}
+
+	mockInfo := func() {
+		gock.New(nodeAPIUrl).
+			Get(iotago.NodeAPIRouteInfo).
+			Reply(200).
+			JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.NodeInfoResponse{NetworkID: networkID, MinPowScore: 4000}})
+	}
+
+	msg, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 1_000_000}).
+		BuildAndSwapToMessageBuilder(iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &inputAddr, Keys: identity}), nil).
+		NetworkIDFromString(networkID).
+		ParentsMessageIDs(tpkg.SortedRand32BytArray(1)).
+		Build()
+	require.NoError(t, err)
+
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+
+	mockInfo()
+	mockNodeOutput(t, inputUTXO, input)
+	require.NoError(t, nodeAPI.ValidateMessage(context.Background(), msg))
+
+	// the node performs the PoW for zero nonces only
+	mockInfo()
+	withNonce := *msg
+	withNonce.Nonce = 1
+	require.True(t, errors.Is(nodeAPI.ValidateMessage(context.Background(), &withNonce), iotago.ErrMessagePoWScoreTooLow))
+
+	mockInfo()
+	otherNetwork := *msg
+	otherNetwork.NetworkID = iotago.NetworkIDFromString("mainnet")
+	require.True(t, errors.Is(nodeAPI.ValidateMessage(context.Background(), &otherNetwork), iotago.ErrNetworkIDMismatch))
+
+	mockInfo()
+	outputJson, err := input.MarshalJSON()
+	require.NoError(t, err)
+	rawMsgOutputJson := json.RawMessage(outputJson)
+	inputUTXOID := inputUTXO.ID()
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteOutput, inputUTXOID.ToHex())).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.NodeOutputResponse{Spent: true, RawOutput: &rawMsgOutputJson}})
+	require.True(t, errors.Is(nodeAPI.ValidateMessage(context.Background(), msg), iotago.ErrInputAlreadySpent))
+
+	// the referenced UTXO holds less than the transaction spends
+	mockInfo()
+	mockNodeOutput(t, inputUTXO, &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 1337})
+	require.True(t, errors.Is(nodeAPI.ValidateMessage(context.Background(), msg), iotago.ErrInputOutputSumMismatch))
+
+	require.True(t, gock.IsDone())
+}
+
 func TestNodeAPI_MessageIDsByIndex(t *testing.T) {
 	defer gock.Off()
 	index := "बेकार पाठ"
@@ -166,6 +863,48 @@ func TestNodeAPI_MessageIDsByIndex(t *testing.T) {
 	require.EqualValues(t, msgIDsByIndex, resMsgIDsByIndex)
 }
 
+func TestNodeAPI_MessagesByIndexes(t *testing.T) {
+	defer gock.Off()
+
+	shared := tpkg.Rand32ByteArray()
+	id1 := tpkg.Rand32ByteArray()
+	id2 := tpkg.Rand32ByteArray()
+
+	mockIndex := func(index string, msgIDs ...[32]byte) {
+		res := &iotago.MessageIDsByIndexResponse{Index: hex.EncodeToString([]byte(index)), MaxResults: 1000}
+		for _, msgID := range msgIDs {
+			res.MessageIDs = append(res.MessageIDs, hex.EncodeToString(msgID[:]))
+		}
+		res.Count = uint32(len(res.MessageIDs))
+		gock.New(nodeAPIUrl).
+			Get(iotago.NodeAPIRouteMessages).
+			MatchParam("index", hex.EncodeToString([]byte(index))).
+			Reply(200).
+			JSON(&iotago.HTTPOkResponseEnvelope{Data: res})
+	}
+
+	mockIndex("first", id1, shared, id1)
+	mockIndex("second", shared, id2)
+
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+	res, err := nodeAPI.MessagesByIndexes(context.Background(), [][]byte{[]byte("first"), []byte("second"), []byte("first")}, 2)
+	require.NoError(t, err)
+	require.Equal(t, map[string]iotago.MessageIDs{
+		"first":  {id1, shared},
+		"second": {shared, id2},
+	}, res)
+	require.True(t, gock.IsDone())
+
+	mockIndex("first", id1)
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteMessages).
+		MatchParam("index", hex.EncodeToString([]byte("second"))).
+		Reply(500).
+		JSON(&iotago.HTTPErrorResponseEnvelope{})
+	_, err = nodeAPI.MessagesByIndexes(context.Background(), [][]byte{[]byte("first"), []byte("second")})
+	require.True(t, errors.Is(err, iotago.ErrHTTPInternalServerError))
+}
+
 func TestNodeAPI_MessageMetadataByMessageID(t *testing.T) {
 	defer gock.Off()
 
@@ -376,6 +1115,170 @@ func TestNodeAPI_OutputIDsByAddress(t *testing.T) {
 	require.EqualValues(t, originResWithUnspent, resp)
 }
 
+func TestNodeAPI_OutputsByBech32Address(t *testing.T) {
+	defer gock.Off()
+
+	ed25519Addr, _ := tpkg.RandEd25519Address()
+	ed25519AddrHex := ed25519Addr.String()
+
+	originOutput, _ := tpkg.RandSigLockedSingleOutput(iotago.AddressEd25519)
+	sigDepJson, err := originOutput.MarshalJSON()
+	require.NoError(t, err)
+	rawMsgSigDepJson := json.RawMessage(sigDepJson)
+
+	utxoInput, _ := tpkg.RandUTXOInput()
+	utxoInputID := utxoInput.ID()
+
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteInfo).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.NodeInfoResponse{Bech32HRP: string(iotago.PrefixTestnet)}})
+
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteAddressEd25519Outputs, ed25519AddrHex)).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.AddressOutputsResponse{
+			AddressType: iotago.AddressEd25519,
+			Address:     ed25519AddrHex,
+			MaxResults:  1000,
+			Count:       1,
+			OutputIDs:   []iotago.OutputIDHex{iotago.OutputIDHex(utxoInputID.ToHex())},
+			LedgerIndex: 1337,
+		}})
+
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteOutput, utxoInputID.ToHex())).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.NodeOutputResponse{
+			TransactionID: hex.EncodeToString(utxoInput.TransactionID[:]),
+			OutputIndex:   utxoInput.TransactionOutputIndex,
+			LedgerIndex:   1337,
+			RawOutput:     &rawMsgSigDepJson,
+		}})
+
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+	resp, outputs, err := nodeAPI.OutputsByBech32Address(context.Background(), ed25519Addr.Bech32(iotago.PrefixTestnet), false)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, resp.Count)
+	require.Len(t, outputs, 1)
+	for input, output := range outputs {
+		require.EqualValues(t, utxoInputID, input.ID())
+		require.EqualValues(t, originOutput, output)
+	}
+
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteInfo).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.NodeInfoResponse{Bech32HRP: string(iotago.PrefixMainnet)}})
+
+	_, _, err = nodeAPI.OutputsByBech32Address(context.Background(), ed25519Addr.Bech32(iotago.PrefixTestnet), false)
+	require.True(t, errors.Is(err, iotago.ErrBech32HRPMismatch))
+
+	_, _, err = nodeAPI.OutputsByBech32Address(context.Background(), "not-a-bech32-address", false)
+	require.Error(t, err)
+}
+
+// mocks the node API to return the given output as spent by the given milestone.
+func mockNodeSpentOutput(t *testing.T, utxoInput *iotago.UTXOInput, output iotago.Output, milestoneIndexSpent uint32) {
+	outputJson, err := output.MarshalJSON()
+	require.NoError(t, err)
+	rawMsgOutputJson := json.RawMessage(outputJson)
+
+	utxoInputID := utxoInput.ID()
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteOutput, utxoInputID.ToHex())).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.NodeOutputResponse{
+			TransactionID:       hex.EncodeToString(utxoInput.TransactionID[:]),
+			OutputIndex:         utxoInput.TransactionOutputIndex,
+			Spent:               true,
+			MilestoneIndexSpent: milestoneIndexSpent,
+			LedgerIndex:         1337,
+			RawOutput:           &rawMsgOutputJson,
+		}})
+}
+
+func TestNodeAPI_OutputsWithMetadataByEd25519Address(t *testing.T) {
+	defer gock.Off()
+
+	ed25519Addr, _ := tpkg.RandEd25519Address()
+	ed25519AddrHex := ed25519Addr.String()
+
+	unspentUTXO, _ := tpkg.RandUTXOInput()
+	unspentOutput := &iotago.SigLockedSingleOutput{Address: ed25519Addr, Amount: 1000}
+	spentUTXO, _ := tpkg.RandUTXOInput()
+	spentOutput := &iotago.SigLockedSingleOutput{Address: ed25519Addr, Amount: 337}
+
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteAddressEd25519Outputs, ed25519AddrHex)).
+		MatchParam("include-spent", "true").
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.AddressOutputsResponse{
+			AddressType: iotago.AddressEd25519,
+			Address:     ed25519AddrHex,
+			MaxResults:  1000,
+			Count:       2,
+			OutputIDs: []iotago.OutputIDHex{
+				iotago.OutputIDHex(unspentUTXO.ID().ToHex()),
+				iotago.OutputIDHex(spentUTXO.ID().ToHex()),
+			},
+			LedgerIndex: 1337,
+		}})
+	mockNodeOutput(t, unspentUTXO, unspentOutput)
+	mockNodeSpentOutput(t, spentUTXO, spentOutput, 42)
+
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+	resp, outputs, err := nodeAPI.OutputsWithMetadataByEd25519Address(context.Background(), ed25519Addr, true)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, resp.Count)
+	require.Len(t, outputs, 2)
+	for input, output := range outputs {
+		switch input.ID() {
+		case unspentUTXO.ID():
+			require.EqualValues(t, unspentOutput, output.Output)
+			require.False(t, output.Spent)
+			require.Zero(t, output.MilestoneIndexSpent)
+		case spentUTXO.ID():
+			require.EqualValues(t, spentOutput, output.Output)
+			require.True(t, output.Spent)
+			require.EqualValues(t, 42, output.MilestoneIndexSpent)
+			require.EqualValues(t, 1337, output.LedgerIndex)
+		default:
+			t.Fatalf("unexpected output %s", input.ID().ToHex())
+		}
+	}
+}
+
+func TestNodeAPI_UnspentInputsForAddress(t *testing.T) {
+	defer gock.Off()
+
+	addr, _ := tpkg.RandEd25519Address()
+	utxoInput1, _ := tpkg.RandUTXOInput()
+	utxoInput2, _ := tpkg.RandUTXOInput()
+	mockNodeAddressOutputs(t, addr, map[*iotago.UTXOInput]iotago.Output{
+		utxoInput1: &iotago.SigLockedSingleOutput{Address: addr, Amount: 1000},
+		utxoInput2: &iotago.SigLockedSingleOutput{Address: addr, Amount: 337},
+	})
+
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+	inputs, err := nodeAPI.UnspentInputsForAddress(context.Background(), addr)
+	require.NoError(t, err)
+	require.True(t, gock.IsDone())
+	require.Len(t, inputs, 2)
+	for _, input := range inputs {
+		require.Equal(t, addr, input.Address)
+	}
+	firstID, secondID := inputs[0].Input.ID(), inputs[1].Input.ID()
+	require.Negative(t, bytes.Compare(firstID[:], secondID[:]))
+
+	sum, err := iotago.NewTransactionBuilder().AddInputs(inputs...).InputSum()
+	require.NoError(t, err)
+	require.EqualValues(t, 1337, sum)
+
+	_, err = nodeAPI.UnspentInputsForAddress(context.Background(), &unknownAddress{})
+	require.True(t, errors.Is(err, iotago.ErrTransactionBuilderUnsupportedAddress))
+}
+
 func TestNodeHTTPAPIClient_Treasury(t *testing.T) {
 	defer gock.Off()
 
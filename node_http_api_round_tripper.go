@@ -0,0 +1,177 @@
+package iotago
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+var (
+	// ErrNoRecordedHTTPExchange gets returned when a ReplayRoundTripper has no recorded HTTPExchange left for a request.
+	ErrNoRecordedHTTPExchange = errors.New("no recorded HTTP exchange for request")
+)
+
+// NewNodeHTTPAPIClientWithRoundTripper returns a new NodeHTTPAPIClient which makes its requests via the given
+// http.RoundTripper instead of the network. This allows to record the exchanges with a real node once via a
+// RecordingRoundTripper and to replay them deterministically, i.e. within tests, via a ReplayRoundTripper:
+//
+//	// capture the responses of a real node once and store them, i.e. as a JSON fixture file
+//	recorder := NewRecordingRoundTripper(http.DefaultTransport)
+//	builder := NewTransactionBuilder().
+//		AddInputsViaNodeQuery(ctx, addr, NewNodeHTTPAPIClientWithRoundTripper(nodeURL, recorder), nil)
+//	fixture, err := json.Marshal(recorder.Exchanges())
+//
+//	// replay the stored responses without any node
+//	var exchanges []*HTTPExchange
+//	err := json.Unmarshal(fixture, &exchanges)
+//	builder := NewTransactionBuilder().
+//		AddInputsViaNodeQuery(ctx, addr, NewNodeHTTPAPIClientWithRoundTripper(nodeURL, NewReplayRoundTripper(exchanges)), nil)
+//
+// The given options are applied after the http.RoundTripper was set, hence WithNodeHTTPAPIClientHTTPClient overrides it.
+func NewNodeHTTPAPIClientWithRoundTripper(baseURL string, roundTripper http.RoundTripper, opts ...NodeHTTPAPIClientOption) *NodeHTTPAPIClient {
+	roundTripperOpt := WithNodeHTTPAPIClientHTTPClient(&http.Client{Transport: roundTripper})
+	return NewNodeHTTPAPIClient(baseURL, append([]NodeHTTPAPIClientOption{roundTripperOpt}, opts...)...)
+}
+
+// HTTPExchange is a request made to a node together with the node's response.
+type HTTPExchange struct {
+	// The method of the request.
+	Method string `json:"method"`
+	// The URL of the request without any user info.
+	URL string `json:"url"`
+	// The body of the request.
+	RequestBody []byte `json:"requestBody,omitempty"`
+	// The status code of the response.
+	StatusCode int `json:"statusCode"`
+	// The headers of the response.
+	Header http.Header `json:"header,omitempty"`
+	// The body of the response.
+	ResponseBody []byte `json:"responseBody,omitempty"`
+}
+
+// NewRecordingRoundTripper creates a new RecordingRoundTripper forwarding the requests to the given http.RoundTripper.
+// If next is nil, http.DefaultTransport is used.
+func NewRecordingRoundTripper(next http.RoundTripper) *RecordingRoundTripper {
+	return &RecordingRoundTripper{next: next}
+}
+
+// RecordingRoundTripper is an http.RoundTripper which forwards requests to another http.RoundTripper
+// and records every request and its response as an HTTPExchange.
+type RecordingRoundTripper struct {
+	next      http.RoundTripper
+	mu        sync.Mutex
+	exchanges []*HTTPExchange
+}
+
+func (r *RecordingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	exchange := &HTTPExchange{Method: req.Method, URL: urlWithoutUserInfo(req)}
+	if req.Body != nil {
+		reqBody, err := ioutil.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unable to record request body: %w", err)
+		}
+		exchange.RequestBody = reqBody
+		req.Body = ioutil.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	next := r.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	res, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("unable to record response body: %w", err)
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+	exchange.StatusCode = res.StatusCode
+	exchange.Header = res.Header.Clone()
+	exchange.ResponseBody = resBody
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exchanges = append(r.exchanges, exchange)
+	return res, nil
+}
+
+// Exchanges returns the HTTPExchanges recorded so far in the order the requests were made.
+func (r *RecordingRoundTripper) Exchanges() []*HTTPExchange {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	exchanges := make([]*HTTPExchange, len(r.exchanges))
+	copy(exchanges, r.exchanges)
+	return exchanges
+}
+
+// NewReplayRoundTripper creates a new ReplayRoundTripper answering requests with the given HTTPExchanges.
+func NewReplayRoundTripper(exchanges []*HTTPExchange) *ReplayRoundTripper {
+	return &ReplayRoundTripper{exchanges: exchanges, used: make([]bool, len(exchanges))}
+}
+
+// ReplayRoundTripper is an http.RoundTripper which answers requests with recorded HTTPExchanges instead of
+// making them. Every request is answered with the first not yet used HTTPExchange of the same method and URL,
+// hence repeated requests to the same URL are answered in the order they were recorded.
+// A request without such an HTTPExchange fails with an error wrapping ErrNoRecordedHTTPExchange.
+type ReplayRoundTripper struct {
+	mu        sync.Mutex
+	exchanges []*HTTPExchange
+	used      []bool
+}
+
+func (r *ReplayRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	url := urlWithoutUserInfo(req)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, exchange := range r.exchanges {
+		if r.used[i] || exchange.Method != req.Method || exchange.URL != url {
+			continue
+		}
+		r.used[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", exchange.StatusCode, http.StatusText(exchange.StatusCode)),
+			StatusCode:    exchange.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        exchange.Header.Clone(),
+			Body:          ioutil.NopCloser(bytes.NewReader(exchange.ResponseBody)),
+			ContentLength: int64(len(exchange.ResponseBody)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrNoRecordedHTTPExchange, req.Method, url)
+}
+
+// Remaining returns the amount of HTTPExchanges which were not yet used to answer a request.
+func (r *ReplayRoundTripper) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var remaining int
+	for _, used := range r.used {
+		if !used {
+			remaining++
+		}
+	}
+	return remaining
+}
+
+// returns the URL of the given request without any user info, so that no credentials get recorded.
+func urlWithoutUserInfo(req *http.Request) string {
+	u := *req.URL
+	u.User = nil
+	return u.String()
+}
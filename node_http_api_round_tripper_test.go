@@ -0,0 +1,57 @@
+package iotago_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestNodeHTTPAPIClientWithRoundTripper_RecordAndReplay(t *testing.T) {
+	defer gock.Off()
+
+	addr, _ := tpkg.RandEd25519Address()
+	utxoInput1, _ := tpkg.RandUTXOInput()
+	utxoInput2, _ := tpkg.RandUTXOInput()
+	mockNodeAddressOutputs(t, addr, map[*iotago.UTXOInput]iotago.Output{
+		utxoInput1: &iotago.SigLockedSingleOutput{Address: addr, Amount: 1000},
+		utxoInput2: &iotago.SigLockedSingleOutput{Address: addr, Amount: 337},
+	})
+
+	// record the exchanges with the mocked node
+	recorder := iotago.NewRecordingRoundTripper(nil)
+	recorded := iotago.NewTransactionBuilder().
+		AddInputsViaNodeQuery(context.Background(), addr, iotago.NewNodeHTTPAPIClientWithRoundTripper(nodeAPIUrl, recorder), nil)
+	require.NoError(t, recorded.Err())
+	require.True(t, gock.IsDone())
+	gock.Off()
+
+	fixture, err := json.Marshal(recorder.Exchanges())
+	require.NoError(t, err)
+	var exchanges []*iotago.HTTPExchange
+	require.NoError(t, json.Unmarshal(fixture, &exchanges))
+	require.Len(t, exchanges, 3)
+
+	// and replay them without the node
+	replayer := iotago.NewReplayRoundTripper(exchanges)
+	nodeAPI := iotago.NewNodeHTTPAPIClientWithRoundTripper(nodeAPIUrl, replayer)
+	replayed := iotago.NewTransactionBuilder().AddInputsViaNodeQuery(context.Background(), addr, nodeAPI, nil)
+	require.NoError(t, replayed.Err())
+	require.Zero(t, replayer.Remaining())
+
+	recordedSum, err := recorded.InputSum()
+	require.NoError(t, err)
+	replayedSum, err := replayed.InputSum()
+	require.NoError(t, err)
+	require.EqualValues(t, 1337, replayedSum)
+	require.Equal(t, recordedSum, replayedSum)
+
+	// every exchange is only replayed once
+	_, err = nodeAPI.OutputIDsByEd25519Address(context.Background(), addr, false)
+	require.True(t, errors.Is(err, iotago.ErrNoRecordedHTTPExchange))
+}
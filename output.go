@@ -1,8 +1,7 @@
 package iotago
 
 import (
-	"encoding/binary"
-	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/iotaledger/hive.go/serializer"
@@ -74,14 +73,12 @@ func (oih OutputIDHex) MustSplitParts() (*TransactionID, uint16) {
 
 // SplitParts returns the transaction ID and output index parts of the hex output ID.
 func (oih OutputIDHex) SplitParts() (*TransactionID, uint16, error) {
-	outputIDBytes, err := hex.DecodeString(string(oih))
+	utxoInputID, err := ParseUTXOInputID(string(oih))
 	if err != nil {
 		return nil, 0, err
 	}
-	var txID TransactionID
-	copy(txID[:], outputIDBytes[:TransactionIDLength])
-	outputIndex := binary.LittleEndian.Uint16(outputIDBytes[TransactionIDLength : TransactionIDLength+serializer.UInt16ByteSize])
-	return &txID, outputIndex, nil
+	txID := utxoInputID.TransactionID()
+	return &txID, utxoInputID.Index(), nil
 }
 
 // MustAsUTXOInput converts the hex output ID to a UTXOInput.
@@ -215,3 +212,24 @@ func jsonOutputSelector(ty int) (JSONSerializable, error) {
 	}
 	return obj, nil
 }
+
+// OutputFromRESTJSON parses the JSON of an output as returned by the node's REST API, i.e. the "output" field of
+// the response to GET /api/v1/outputs/:outputID, into the Output implementation denoted by its "type" field.
+// The returned Output can be used as the Output of a ToBeSignedUTXOInput. As a TreasuryOutput does not implement
+// Output, its JSON is rejected with an error wrapping ErrUnknownOutputType.
+func OutputFromRESTJSON(data []byte) (Output, error) {
+	raw := json.RawMessage(data)
+	jsonSeri, err := DeserializeObjectFromJSON(&raw, jsonOutputSelector)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse output from JSON: %w", err)
+	}
+	seri, err := jsonSeri.ToSerializable()
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse output from JSON: %w", err)
+	}
+	output, isOutput := seri.(Output)
+	if !isOutput {
+		return nil, fmt.Errorf("%w: JSON holds a %T", ErrUnknownOutputType, seri)
+	}
+	return output, nil
+}
@@ -1,7 +1,9 @@
 package iotago_test
 
 import (
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"github.com/iotaledger/hive.go/serializer"
 	"github.com/iotaledger/iota.go/v2/tpkg"
 	"testing"
@@ -15,6 +17,66 @@ func TestOutputSelector(t *testing.T) {
 	assert.True(t, errors.Is(err, iotago.ErrUnknownOutputType))
 }
 
+func TestOutputFromRESTJSON(t *testing.T) {
+	addr, _ := tpkg.RandEd25519Address()
+	addrHex := hex.EncodeToString(addr[:])
+
+	type test struct {
+		name   string
+		json   string
+		target iotago.Output
+		err    error
+	}
+	tests := []test{
+		{
+			name:   "ok - sig locked single output",
+			json:   fmt.Sprintf(`{"type":0,"address":{"type":0,"address":"%s"},"amount":1000000}`, addrHex),
+			target: &iotago.SigLockedSingleOutput{Address: addr, Amount: 1_000_000},
+		},
+		{
+			name:   "ok - sig locked dust allowance output",
+			json:   fmt.Sprintf(`{"type":1,"address":{"type":0,"address":"%s"},"amount":1000000}`, addrHex),
+			target: &iotago.SigLockedDustAllowanceOutput{Address: addr, Amount: 1_000_000},
+		},
+		{
+			name: "err - treasury output can not be used as input",
+			json: `{"type":2,"amount":1337}`,
+			err:  iotago.ErrUnknownOutputType,
+		},
+		{
+			name: "err - unknown output type",
+			json: `{"type":100,"amount":1337}`,
+			err:  iotago.ErrUnknownOutputType,
+		},
+		{
+			name: "err - unknown address type",
+			json: fmt.Sprintf(`{"type":0,"address":{"type":100,"address":"%s"},"amount":1000000}`, addrHex),
+			err:  iotago.ErrUnknownAddrType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := iotago.OutputFromRESTJSON([]byte(tt.json))
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualValues(t, tt.target, output)
+		})
+	}
+
+	// the parsed output can directly be used as the output of an input
+	output, err := iotago.OutputFromRESTJSON([]byte(tests[0].json))
+	assert.NoError(t, err)
+	utxoInput, _ := tpkg.RandUTXOInput()
+	sum, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: addr, Input: utxoInput, Output: output}).
+		InputSum()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1_000_000, sum)
+}
+
 func TestSigLockedSingleOutput_Deserialize(t *testing.T) {
 	type test struct {
 		name   string
@@ -77,6 +139,35 @@ func TestSigLockedSingleOutput_Serialize(t *testing.T) {
 	}
 }
 
+func TestNewOutputs(t *testing.T) {
+	addr, _ := tpkg.RandEd25519Address()
+
+	type test struct {
+		name   string
+		source iotago.Output
+		err    error
+	}
+	tests := []test{
+		{"ok - single output", iotago.NewSigLockedSingleOutput(addr, 1337), nil},
+		{"ok - dust allowance output", iotago.NewSigLockedDustAllowanceOutput(addr, iotago.OutputSigLockedDustAllowanceOutputMinDeposit), nil},
+		{"err - zero deposit", iotago.NewSigLockedSingleOutput(addr, 0), iotago.ErrDepositAmountMustBeGreaterThanZero},
+		{"err - dust allowance below min deposit", iotago.NewSigLockedDustAllowanceOutput(addr, 1), iotago.ErrOutputDustAllowanceLessThanMinDeposit},
+		{"err - exceeds total supply", iotago.NewSigLockedSingleOutput(addr, iotago.TokenSupply+1), iotago.ErrOutputDepositsMoreThanTotalSupply},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.source.Serialize(serializer.DeSeriModePerformValidation)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err))
+
+				// the invalid output can still be serialized without validation
+				_, err = tt.source.Serialize(serializer.DeSeriModeNoValidation)
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func TestOutputsValidatorFunc(t *testing.T) {
 	type args struct {
 		outputs serializer.Serializables
@@ -56,6 +56,20 @@ func (r *Receipt) Sum() uint64 {
 	return sum
 }
 
+// Entries returns the MigratedFundsEntry items within the Receipt.
+// This function panics if any of the Receipt.Funds is not a MigratedFundsEntry.
+func (r *Receipt) Entries() []*MigratedFundsEntry {
+	entries := make([]*MigratedFundsEntry, len(r.Funds))
+	for i, item := range r.Funds {
+		entry, ok := item.(*MigratedFundsEntry)
+		if !ok {
+			panic("receipt contains non migrated funds entry")
+		}
+		entries[i] = entry
+	}
+	return entries
+}
+
 // Treasury returns the TreasuryTransaction within the receipt or nil if none is contained.
 // This function panics if the Receipt.Transaction is not nil and not a TreasuryTransaction.
 func (r *Receipt) Treasury() *TreasuryTransaction {
@@ -266,8 +280,7 @@ func ValidateReceipt(receipt *Receipt, prevTreasuryOutput *TreasuryOutput) error
 
 	seenTailTxHashes := make(map[LegacyTailTransactionHash]int)
 	var migratedFundsSum uint64
-	for fIndex, f := range receipt.Funds {
-		entry := f.(*MigratedFundsEntry)
+	for fIndex, entry := range receipt.Entries() {
 		if prevIndex, seen := seenTailTxHashes[entry.TailTransactionHash]; seen {
 			return fmt.Errorf("%w: tail transaction hash at index %d occurrs multiple times (previous %d)", ErrInvalidReceipt, fIndex, prevIndex)
 		}
@@ -288,6 +301,9 @@ func ValidateReceipt(receipt *Receipt, prevTreasuryOutput *TreasuryOutput) error
 
 	prevTreasury := prevTreasuryOutput.Amount
 	newTreasury := treasuryTransaction.Output.(*TreasuryOutput).Amount
+	if migratedFundsSum > prevTreasury {
+		return fmt.Errorf("%w: migrated funds sum %d exceeds the previous treasury amount %d", ErrInvalidReceipt, migratedFundsSum, prevTreasury)
+	}
 	if prevTreasury-migratedFundsSum != newTreasury {
 		return fmt.Errorf("%w: new treasury amount mismatch, prev %d, delta %d (migrated funds), new %d", ErrInvalidReceipt, prevTreasury, migratedFundsSum, newTreasury)
 	}
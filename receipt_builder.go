@@ -19,6 +19,9 @@ func NewReceiptBuilder(migratedAt uint32) *ReceiptBuilder {
 // ReceiptBuilder is used to easily build up a Receipt.
 type ReceiptBuilder struct {
 	r *Receipt
+	// the treasury funding the migrated funds, set via FundFromTreasury
+	prevTreasuryInput  *TreasuryInput
+	prevTreasuryOutput *TreasuryOutput
 }
 
 // AddEntry adds the given MigratedFundsEntry to the receipt.
@@ -27,17 +30,68 @@ func (rb *ReceiptBuilder) AddEntry(entry *MigratedFundsEntry) *ReceiptBuilder {
 	return rb
 }
 
+// AddMigratedFunds adds a MigratedFundsEntry for the funds of the given legacy migration bundle
+// which are deposited to the given address.
+func (rb *ReceiptBuilder) AddMigratedFunds(tailTxHash LegacyTailTransactionHash, addr Address, deposit uint64) *ReceiptBuilder {
+	return rb.AddEntry(&MigratedFundsEntry{TailTransactionHash: tailTxHash, Address: addr, Deposit: deposit})
+}
+
+// Final marks the receipt as the final one for its migrated at index.
+func (rb *ReceiptBuilder) Final(final bool) *ReceiptBuilder {
+	rb.r.Final = final
+	return rb
+}
+
+// FundFromTreasury lets the Build function produce the TreasuryTransaction of the receipt:
+// the given TreasuryInput references the milestone which generated the given previous TreasuryOutput
+// and the new TreasuryOutput holds the previous treasury amount minus the sum of the migrated funds.
+// As the TreasuryInput is unlocked via the receipt itself, no signatures are involved and the resulting
+// receipt can be embedded as is into the milestone issued by the coordinator.
+// This function overrides any previously added TreasuryTransaction.
+func (rb *ReceiptBuilder) FundFromTreasury(input *TreasuryInput, prevTreasuryOutput *TreasuryOutput) *ReceiptBuilder {
+	rb.prevTreasuryInput = input
+	rb.prevTreasuryOutput = prevTreasuryOutput
+	rb.r.Transaction = nil
+	return rb
+}
+
 // AddTreasuryTransaction adds the given TreasuryTransaction to the receipt.
 // This function overrides the previously added TreasuryTransaction.
 func (rb *ReceiptBuilder) AddTreasuryTransaction(tx *TreasuryTransaction) *ReceiptBuilder {
 	rb.r.Transaction = tx
+	rb.prevTreasuryInput = nil
+	rb.prevTreasuryOutput = nil
 	return rb
 }
 
-// Build builds the Receipt.
+// Build builds the Receipt. If the receipt is funded via FundFromTreasury, its TreasuryTransaction is produced
+// and the receipt is validated against the previous TreasuryOutput using ValidateReceipt.
 func (rb *ReceiptBuilder) Build() (*Receipt, error) {
+	if rb.prevTreasuryOutput != nil {
+		if len(rb.r.Funds) == 0 {
+			return nil, fmt.Errorf("unable to build receipt: %w: no migrated funds", ErrInvalidReceipt)
+		}
+		migratedFundsSum := rb.r.Sum()
+		if migratedFundsSum > rb.prevTreasuryOutput.Amount {
+			return nil, fmt.Errorf("unable to build receipt: %w: migrated funds sum %d exceeds the previous treasury amount %d", ErrInvalidReceipt, migratedFundsSum, rb.prevTreasuryOutput.Amount)
+		}
+		treasuryTx, err := NewTransactionBuilder().
+			AddTreasuryInput(rb.prevTreasuryInput).
+			AddTreasuryOutput(&TreasuryOutput{Amount: rb.prevTreasuryOutput.Amount - migratedFundsSum}).
+			BuildTreasuryTransaction()
+		if err != nil {
+			return nil, fmt.Errorf("unable to build receipt: %w", err)
+		}
+		rb.r.Transaction = treasuryTx
+	}
+
 	if _, err := rb.r.Serialize(serializer.DeSeriModePerformValidation | serializer.DeSeriModePerformLexicalOrdering); err != nil {
 		return nil, fmt.Errorf("unable to build receipt: %w", err)
 	}
+	if rb.prevTreasuryOutput != nil {
+		if err := ValidateReceipt(rb.r, rb.prevTreasuryOutput); err != nil {
+			return nil, fmt.Errorf("unable to build receipt: %w", err)
+		}
+	}
 	return rb.r, nil
 }
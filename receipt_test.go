@@ -149,6 +149,15 @@ func TestValidateReceipts(t *testing.T) {
 			}).AddTreasuryTransaction(sampleTreasuryTx).Build()
 			return test{"err - invalid new treasury amount", receipt, currentTreasury, iotago.ErrInvalidReceipt}
 		}(),
+		func() test {
+			addr, _ := tpkg.RandEd25519Address()
+			receipt, _ := iotago.NewReceiptBuilder(100).AddEntry(&iotago.MigratedFundsEntry{
+				TailTransactionHash: tpkg.Rand49ByteArray(),
+				Address:             addr,
+				Deposit:             7_000_000,
+			}).AddTreasuryTransaction(sampleTreasuryTx).Build()
+			return test{"err - migrated more than previous treasury", receipt, &iotago.TreasuryOutput{Amount: 5_000_000}, iotago.ErrInvalidReceipt}
+		}(),
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -161,3 +170,94 @@ func TestValidateReceipts(t *testing.T) {
 		})
 	}
 }
+
+func TestReceipt_Entries(t *testing.T) {
+	receipt, _ := tpkg.RandReceipt()
+	entries := receipt.Entries()
+	require.Len(t, entries, len(receipt.Funds))
+
+	var sum uint64
+	for i, entry := range entries {
+		require.Same(t, receipt.Funds[i], entry)
+		sum += entry.Deposit
+	}
+	require.Equal(t, receipt.Sum(), sum)
+}
+
+func TestReceiptBuilder_FundFromTreasury(t *testing.T) {
+	addr, _ := tpkg.RandEd25519Address()
+	treasuryInputID := tpkg.Rand32ByteArray()
+	treasuryInput := &iotago.TreasuryInput{}
+	copy(treasuryInput[:], treasuryInputID[:])
+	prevTreasury := &iotago.TreasuryOutput{Amount: 10_000_000}
+
+	type test struct {
+		name        string
+		builder     *iotago.ReceiptBuilder
+		newTreasury uint64
+		buildErr    error
+	}
+
+	tests := []test{
+		{
+			name: "ok",
+			builder: iotago.NewReceiptBuilder(100).
+				AddMigratedFunds(tpkg.Rand49ByteArray(), addr, 3_000_000).
+				AddMigratedFunds(tpkg.Rand49ByteArray(), addr, 2_000_000).
+				Final(true).
+				FundFromTreasury(treasuryInput, prevTreasury),
+			newTreasury: 5_000_000,
+		},
+		{
+			name: "ok - migrates the entire treasury",
+			builder: iotago.NewReceiptBuilder(100).
+				AddMigratedFunds(tpkg.Rand49ByteArray(), addr, 10_000_000).
+				FundFromTreasury(treasuryInput, prevTreasury),
+			newTreasury: 0,
+		},
+		{
+			name: "err - exceeds previous treasury",
+			builder: iotago.NewReceiptBuilder(100).
+				AddMigratedFunds(tpkg.Rand49ByteArray(), addr, 11_000_000).
+				FundFromTreasury(treasuryInput, prevTreasury),
+			buildErr: iotago.ErrInvalidReceipt,
+		},
+		{
+			name: "err - deposit below minimum",
+			builder: iotago.NewReceiptBuilder(100).
+				AddMigratedFunds(tpkg.Rand49ByteArray(), addr, 1000).
+				FundFromTreasury(treasuryInput, prevTreasury),
+			buildErr: iotago.ErrInvalidReceipt,
+		},
+		{
+			name: "err - no migrated funds",
+			builder: iotago.NewReceiptBuilder(100).
+				FundFromTreasury(treasuryInput, prevTreasury),
+			buildErr: iotago.ErrInvalidReceipt,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			receipt, err := test.builder.Build()
+			if test.buildErr != nil {
+				assert.True(t, errors.Is(err, test.buildErr))
+				return
+			}
+			require.NoError(t, err)
+
+			treasuryTx := receipt.Treasury()
+			require.NotNil(t, treasuryTx)
+			require.Equal(t, treasuryInput, treasuryTx.Input)
+			require.EqualValues(t, test.newTreasury, treasuryTx.Output.(*iotago.TreasuryOutput).Amount)
+			require.NoError(t, iotago.ValidateReceipt(receipt, prevTreasury))
+
+			receiptData, err := receipt.Serialize(serializer.DeSeriModePerformValidation)
+			require.NoError(t, err)
+			desReceipt := &iotago.Receipt{}
+			_, err = desReceipt.Deserialize(receiptData, serializer.DeSeriModePerformValidation)
+			require.NoError(t, err)
+			require.EqualValues(t, receipt, desReceipt)
+		})
+	}
+}
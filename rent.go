@@ -0,0 +1,41 @@
+package iotago
+
+import "github.com/iotaledger/hive.go/serializer"
+
+// RentStructure defines the parameters of the storage deposit (rent) which the outputs of a transaction must lock
+// in relation to the virtual bytes they occupy in the ledger. Virtual bytes weigh the bytes an output is stored with:
+// the bytes of the key identifying the output in the ledger weigh VBFactorKey, the bytes of its data VBFactorData.
+type RentStructure struct {
+	// The amount of tokens to lock per virtual byte.
+	VByteCost uint64
+	// The weight of bytes holding data.
+	VBFactorData uint64
+	// The weight of bytes used as keys within the ledger.
+	VBFactorKey uint64
+}
+
+// VBytesOutput is implemented by outputs which define the virtual bytes they occupy in the ledger
+// under a given RentStructure. Outputs not implementing it weigh their serialized bytes as data.
+type VBytesOutput interface {
+	// VBytes returns the virtual bytes the output occupies in the ledger under the given RentStructure.
+	VBytes(rentStruct *RentStructure) uint64
+}
+
+// MinStorageDeposit returns the minimum deposit the given Output must hold under the RentStructure.
+func (r *RentStructure) MinStorageDeposit(output Output) uint64 {
+	return r.VByteCost * r.outputVBytes(output)
+}
+
+// returns the virtual bytes of the given output, falling back to its serialized size if it is no VBytesOutput.
+func (r *RentStructure) outputVBytes(output Output) uint64 {
+	if vBytesOutput, ok := output.(VBytesOutput); ok {
+		return vBytesOutput.VBytes(r)
+	}
+	data, _ := output.Serialize(serializer.DeSeriModeNoValidation)
+	return r.vBytes(len(data))
+}
+
+// returns the virtual bytes of an output with the given serialized data size, which is keyed by its UTXOInputID.
+func (r *RentStructure) vBytes(dataSize int) uint64 {
+	return r.VBFactorKey*uint64(len(UTXOInputID{})) + r.VBFactorData*uint64(dataSize)
+}
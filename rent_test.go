@@ -0,0 +1,29 @@
+package iotago_test
+
+import (
+	"testing"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRentStructure_MinStorageDeposit(t *testing.T) {
+	rentStruct := &iotago.RentStructure{VByteCost: 5, VBFactorData: 1, VBFactorKey: 10}
+	addr, _ := tpkg.RandEd25519Address()
+
+	tests := []struct {
+		name       string
+		output     iotago.Output
+		minDeposit uint64
+	}{
+		{"sig locked single output", &iotago.SigLockedSingleOutput{Address: addr, Amount: 1}, 5 * (10*34 + iotago.SigLockedSingleOutputEd25519AddrBytesSize)},
+		{"sig locked dust allowance output", &iotago.SigLockedDustAllowanceOutput{Address: addr, Amount: 1}, 5 * (10*34 + iotago.SigLockedDustAllowanceOutputEd25519AddrBytesSize)},
+		{"output without vbytes", struct{ iotago.Output }{&iotago.SigLockedSingleOutput{Address: addr, Amount: 1}}, 5 * (10*34 + iotago.SigLockedSingleOutputEd25519AddrBytesSize)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualValues(t, tt.minDeposit, rentStruct.MinStorageDeposit(tt.output))
+		})
+	}
+}
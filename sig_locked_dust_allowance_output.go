@@ -25,6 +25,13 @@ type SigLockedDustAllowanceOutput struct {
 	Amount uint64 `json:"amount"`
 }
 
+// NewSigLockedDustAllowanceOutput creates a new SigLockedDustAllowanceOutput depositing the given amount onto the given address.
+// The arguments are not validated, which only happens on serialization with validation,
+// so that also invalid outputs can be constructed, e.g. for fuzzing.
+func NewSigLockedDustAllowanceOutput(addr serializer.Serializable, amount uint64) *SigLockedDustAllowanceOutput {
+	return &SigLockedDustAllowanceOutput{Address: addr, Amount: amount}
+}
+
 func (s *SigLockedDustAllowanceOutput) Type() OutputType {
 	return OutputSigLockedDustAllowanceOutput
 }
@@ -37,6 +44,10 @@ func (s *SigLockedDustAllowanceOutput) Deposit() (uint64, error) {
 	return s.Amount, nil
 }
 
+func (s *SigLockedDustAllowanceOutput) VBytes(rentStruct *RentStructure) uint64 {
+	return rentStruct.vBytes(SigLockedDustAllowanceOutputEd25519AddrBytesSize)
+}
+
 func (s *SigLockedDustAllowanceOutput) Deserialize(data []byte, deSeriMode serializer.DeSerializationMode) (int, error) {
 	return serializer.NewDeserializer(data).
 		AbortIf(func(err error) error {
@@ -24,6 +24,13 @@ type SigLockedSingleOutput struct {
 	Amount uint64 `json:"amount"`
 }
 
+// NewSigLockedSingleOutput creates a new SigLockedSingleOutput depositing the given amount onto the given address.
+// The arguments are not validated, which only happens on serialization with validation,
+// so that also invalid outputs can be constructed, e.g. for fuzzing.
+func NewSigLockedSingleOutput(addr serializer.Serializable, amount uint64) *SigLockedSingleOutput {
+	return &SigLockedSingleOutput{Address: addr, Amount: amount}
+}
+
 func (s *SigLockedSingleOutput) Type() OutputType {
 	return OutputSigLockedSingleOutput
 }
@@ -36,6 +43,10 @@ func (s *SigLockedSingleOutput) Deposit() (uint64, error) {
 	return s.Amount, nil
 }
 
+func (s *SigLockedSingleOutput) VBytes(rentStruct *RentStructure) uint64 {
+	return rentStruct.vBytes(SigLockedSingleOutputEd25519AddrBytesSize)
+}
+
 func (s *SigLockedSingleOutput) Deserialize(data []byte, deSeriMode serializer.DeSerializationMode) (int, error) {
 	return serializer.NewDeserializer(data).
 		AbortIf(func(err error) error {
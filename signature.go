@@ -28,6 +28,8 @@ var (
 	ErrEd25519PubKeyAndAddrMismatch = errors.New("public key and address do not correspond to each other (Ed25519)")
 	// ErrEd25519SignatureInvalid gets returned for invalid an Ed25519Signature.
 	ErrEd25519SignatureInvalid = errors.New("signature is invalid (Ed25519")
+	// ErrSignatureAndAddrTypeMismatch gets returned when a signature is verified against an address of a type it can not unlock.
+	ErrSignatureAndAddrTypeMismatch = errors.New("signature type does not correspond to address type")
 )
 
 // SignatureSelector implements SerializableSelectorFunc for signature types.
@@ -42,6 +44,22 @@ func SignatureSelector(sigType uint32) (serializer.Serializable, error) {
 	return seri, nil
 }
 
+// VerifySignature verifies that the given signature is a valid signature over msg made by the key of the given address:
+// the signature must be of the type corresponding to the address type (an Ed25519Signature for an Ed25519Address),
+// its public key must correspond to the address and the signature itself must verify.
+func VerifySignature(addr Address, sig serializer.Serializable, msg []byte) error {
+	switch s := sig.(type) {
+	case *Ed25519Signature:
+		edAddr, isEdAddr := addr.(*Ed25519Address)
+		if !isEdAddr {
+			return fmt.Errorf("%w: Ed25519 signature can not unlock address of type %T", ErrSignatureAndAddrTypeMismatch, addr)
+		}
+		return s.Valid(msg, edAddr)
+	default:
+		return fmt.Errorf("%w: %T", ErrUnknownSignatureType, sig)
+	}
+}
+
 // Ed25519Signature defines an Ed25519 signature.
 type Ed25519Signature struct {
 	// The public key used to verify the given signature.
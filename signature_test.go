@@ -9,6 +9,7 @@ import (
 
 	"github.com/iotaledger/hive.go/serializer"
 	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
 	"github.com/iotaledger/iota.go/v2/tpkg"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -19,6 +20,43 @@ func TestSignatureSelector(t *testing.T) {
 	assert.True(t, errors.Is(err, iotago.ErrUnknownSignatureType))
 }
 
+func TestVerifySignature(t *testing.T) {
+	prvKey := tpkg.RandEd25519PrivateKey()
+	addr := iotago.AddressFromEd25519PubKey(prvKey.Public().(ed25519.PublicKey))
+	msg := []byte("signing message")
+
+	sig, err := iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &addr, Keys: prvKey}).Sign(&addr, msg)
+	require.NoError(t, err)
+
+	otherAddr, _ := tpkg.RandEd25519Address()
+
+	type test struct {
+		name string
+		addr iotago.Address
+		sig  serializer.Serializable
+		msg  []byte
+		err  error
+	}
+	tests := []test{
+		{name: "ok", addr: &addr, sig: sig, msg: msg},
+		{name: "err - other message", addr: &addr, sig: sig, msg: []byte("other message"), err: iotago.ErrEd25519SignatureInvalid},
+		{name: "err - other address", addr: otherAddr, sig: sig, msg: msg, err: iotago.ErrEd25519PubKeyAndAddrMismatch},
+		{name: "err - address type mismatch", addr: &unknownAddress{Ed25519Address: addr}, sig: sig, msg: msg, err: iotago.ErrSignatureAndAddrTypeMismatch},
+		{name: "err - unknown signature type", addr: &addr, sig: &iotago.UTXOInput{}, msg: msg, err: iotago.ErrUnknownSignatureType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := iotago.VerifySignature(tt.addr, tt.sig, tt.msg)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func TestEd25519Signature_Deserialize(t *testing.T) {
 	type test struct {
 		name   string
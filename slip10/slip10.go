@@ -0,0 +1,125 @@
+// Package slip10 implements the SLIP-10 hierarchical deterministic key derivation for Ed25519.
+// See https://github.com/satoshilabs/slips/blob/master/slip-0010.md for the specification.
+package slip10
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/iotaledger/iota.go/v2/ed25519"
+)
+
+const (
+	// Hardened is the offset from which on indices denote hardened derivation.
+	Hardened uint32 = 1 << 31
+	// seedModifier is the HMAC key used to derive the master key from the seed.
+	seedModifier = "ed25519 seed"
+)
+
+var (
+	// ErrNotHardened gets returned if a path contains a non-hardened index, which Ed25519 does not support.
+	ErrNotHardened = errors.New("only hardened indices are supported for Ed25519")
+	// ErrInvalidPath gets returned for malformed derivation path strings.
+	ErrInvalidPath = errors.New("invalid derivation path")
+)
+
+// Path is a derivation path made of indices.
+type Path []uint32
+
+// ParsePath parses a derivation path like "m/44'/4218'/0'/0'/0'".
+// Indices marked with a "'" or "H" suffix are hardened.
+func ParsePath(s string) (Path, error) {
+	segments := strings.Split(s, "/")
+	if segments[0] != "m" {
+		return nil, fmt.Errorf("%w: %s must start with 'm'", ErrInvalidPath, s)
+	}
+
+	path := make(Path, 0, len(segments)-1)
+	for _, segment := range segments[1:] {
+		var hardened bool
+		if strings.HasSuffix(segment, "'") || strings.HasSuffix(segment, "H") {
+			hardened = true
+			segment = segment[:len(segment)-1]
+		}
+
+		index, err := strconv.ParseUint(segment, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid index %s in %s", ErrInvalidPath, segment, s)
+		}
+
+		if hardened {
+			index += uint64(Hardened)
+		}
+		path = append(path, uint32(index))
+	}
+
+	return path, nil
+}
+
+func (p Path) String() string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, index := range p {
+		if index >= Hardened {
+			fmt.Fprintf(&b, "/%d'", index-Hardened)
+			continue
+		}
+		fmt.Fprintf(&b, "/%d", index)
+	}
+	return b.String()
+}
+
+// Key is an extended Ed25519 key made of the private key seed and the chain code.
+type Key struct {
+	// The 32 byte private key seed.
+	Key []byte
+	// The 32 byte chain code.
+	ChainCode []byte
+}
+
+// PrivateKey returns the Ed25519 private key of the Key.
+func (k *Key) PrivateKey() ed25519.PrivateKey {
+	return ed25519.NewKeyFromSeed(k.Key)
+}
+
+// NewMasterKey derives the master key from the given seed.
+func NewMasterKey(seed []byte) *Key {
+	return newKey([]byte(seedModifier), seed)
+}
+
+// DeriveChild derives the hardened child key at the given index.
+func (k *Key) DeriveChild(index uint32) (*Key, error) {
+	if index < Hardened {
+		return nil, fmt.Errorf("%w: index %d", ErrNotHardened, index)
+	}
+
+	data := make([]byte, 1+len(k.Key)+4)
+	copy(data[1:], k.Key)
+	binary.BigEndian.PutUint32(data[1+len(k.Key):], index)
+
+	return newKey(k.ChainCode, data), nil
+}
+
+// DeriveKeyFromPath derives the key at the given path from the given seed.
+func DeriveKeyFromPath(seed []byte, path Path) (*Key, error) {
+	key := NewMasterKey(seed)
+	for _, index := range path {
+		var err error
+		if key, err = key.DeriveChild(index); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+func newKey(hmacKey []byte, data []byte) *Key {
+	h := hmac.New(sha512.New, hmacKey)
+	_, _ = h.Write(data)
+	sum := h.Sum(nil)
+	return &Key{Key: sum[:32], ChainCode: sum[32:]}
+}
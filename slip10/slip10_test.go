@@ -0,0 +1,93 @@
+package slip10_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/iotaledger/iota.go/v2/slip10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// test vector 1 for ed25519 of the SLIP-10 specification.
+func TestDeriveKeyFromPath(t *testing.T) {
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+
+	tests := []struct {
+		path      string
+		chainCode string
+		key       string
+		publicKey string
+	}{
+		{
+			path:      "m",
+			chainCode: "90046a93de5380a72b5e45010748567d5ea02bbf6522f979e05c0d8d8ca9fffb",
+			key:       "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7",
+			publicKey: "a4b2856bfec510abab89753fac1ac0e1112364e7d250545963f135f2a33188ed",
+		},
+		{
+			path:      "m/0H",
+			chainCode: "8b59aa11380b624e81507a27fedda59fea6d0b779a778918a2fd3590e16e9c69",
+			key:       "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3",
+			publicKey: "8c8a13df77a28f3445213a0f432fde644acaa215fc72dcdf300d5efaa85d350c",
+		},
+		{
+			path:      "m/0H/1H",
+			chainCode: "a320425f77d1b5c2505a6b1b27382b37368ee640e3557c315416801243552f14",
+			key:       "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2",
+			publicKey: "1932a5270f335bed617d5b935c80aedb1a35bd9fc1e31acafd5372c30f5c1187",
+		},
+		{
+			path:      "m/0H/1H/2H",
+			chainCode: "2e69929e00b5ab250f49c3fb1c12f252de4fed2c1db88387094a0f8c4c9ccd6c",
+			key:       "92a5b23c0b8a99e37d07df3fb9966917f5d06e02ddbd909c7e184371463e9fc9",
+			publicKey: "ae98736566d30ed0e9d2f4486a64bc95740d89c7db33f52121f8ea8f76ff0fc1",
+		},
+		{
+			path:      "m/0H/1H/2H/2H",
+			chainCode: "8f6d87f93d750e0efccda017d662a1b31a266e4a6f5993b15f5c1f07f74dd5cc",
+			key:       "30d1dc7e5fc04c31219ab25a27ae00b50f6fd66622f6e9c913253d6511d1e662",
+			publicKey: "8abae2d66361c879b900d204ad2cc4984fa2aa344dd7ddc46007329ac76c429c",
+		},
+		{
+			path:      "m/0H/1H/2H/2H/1000000000H",
+			chainCode: "68789923a0cac2cd5a29172a475fe9e0fb14cd6adb5ad98a3fa70333e7afa230",
+			key:       "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793",
+			publicKey: "3c24da049451555d51a7014a37337aa4e12d41e485abccfa46b47dfb2af54b7a",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			path, err := slip10.ParsePath(test.path)
+			require.NoError(t, err)
+
+			key, err := slip10.DeriveKeyFromPath(seed, path)
+			require.NoError(t, err)
+			assert.Equal(t, test.chainCode, hex.EncodeToString(key.ChainCode))
+			assert.Equal(t, test.key, hex.EncodeToString(key.Key))
+			assert.Equal(t, test.publicKey, hex.EncodeToString(key.PrivateKey().Public().(ed25519.PublicKey)))
+		})
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	path, err := slip10.ParsePath("m/44'/4218'/0'/0'/1'")
+	require.NoError(t, err)
+	require.Equal(t, slip10.Path{44 + slip10.Hardened, 4218 + slip10.Hardened, slip10.Hardened, slip10.Hardened, 1 + slip10.Hardened}, path)
+	require.Equal(t, "m/44'/4218'/0'/0'/1'", path.String())
+
+	_, err = slip10.ParsePath("44'/4218'")
+	require.True(t, errors.Is(err, slip10.ErrInvalidPath))
+
+	_, err = slip10.ParsePath("m/44'/abc'")
+	require.True(t, errors.Is(err, slip10.ErrInvalidPath))
+
+	path, err = slip10.ParsePath("m/44'/0")
+	require.NoError(t, err)
+	_, err = slip10.DeriveKeyFromPath([]byte{1, 2, 3}, path)
+	require.True(t, errors.Is(err, slip10.ErrNotHardened))
+}
@@ -0,0 +1,97 @@
+package iotago
+
+import (
+	"context"
+	"sync"
+)
+
+// NewSynchronizedTransactionBuilder creates a new SynchronizedTransactionBuilder.
+func NewSynchronizedTransactionBuilder() *SynchronizedTransactionBuilder {
+	return &SynchronizedTransactionBuilder{b: NewTransactionBuilder()}
+}
+
+// SynchronizedTransactionBuilder wraps a TransactionBuilder so that inputs and outputs can be added from multiple
+// goroutines concurrently. Every call acquires a mutex and the Build functions operate on a copy of the builder's
+// state as of the call, hence the added inputs and outputs stay untouched by a Build and building concurrently to
+// adding is safe. This comes at the cost of lock contention and of copying the inputs, outputs and mappings on
+// every Build, therefore the unsynchronized TransactionBuilder should be preferred if a single goroutine builds up
+// the transaction.
+type SynchronizedTransactionBuilder struct {
+	mu sync.Mutex
+	b  *TransactionBuilder
+}
+
+// Err returns the error which occurred during the previous builder steps or nil if none occurred.
+func (sb *SynchronizedTransactionBuilder) Err() error {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return sb.b.Err()
+}
+
+// AddInput adds the given input to the builder. See TransactionBuilder.AddInput.
+func (sb *SynchronizedTransactionBuilder) AddInput(input *ToBeSignedUTXOInput) *SynchronizedTransactionBuilder {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	sb.b.AddInput(input)
+	return sb
+}
+
+// AddInputs adds the given inputs to the builder in the given order. See TransactionBuilder.AddInputs.
+func (sb *SynchronizedTransactionBuilder) AddInputs(inputs ...*ToBeSignedUTXOInput) *SynchronizedTransactionBuilder {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	sb.b.AddInputs(inputs...)
+	return sb
+}
+
+// AddInputsViaNodeQuery adds the unspent outputs of the given address passing the filter as inputs to the builder.
+// The node is queried without holding the builder's mutex, so that multiple addresses can be queried concurrently,
+// while the queried outputs are added under the mutex respecting the builder's node query settings.
+// See TransactionBuilder.AddInputsViaNodeQuery.
+func (sb *SynchronizedTransactionBuilder) AddInputsViaNodeQuery(ctx context.Context, addr Address, nodeHTTPAPIClient *NodeHTTPAPIClient, filter TransactionBuilderInputFilter) *SynchronizedTransactionBuilder {
+	sb.mu.Lock()
+	ctx, cancel := sb.b.nodeQueryContext(ctx)
+	sb.mu.Unlock()
+	defer cancel()
+
+	unspentOutputs, err := unspentOutputsByAddress(ctx, addr, nodeHTTPAPIClient)
+
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	if err != nil {
+		sb.b.occurredBuildErr = err
+		return sb
+	}
+	sb.b.addUnspentOutputsAsInputs(ctx, addr, unspentOutputs, filter)
+	return sb
+}
+
+// AddOutput adds the given output to the builder. See TransactionBuilder.AddOutput.
+func (sb *SynchronizedTransactionBuilder) AddOutput(output Output) *SynchronizedTransactionBuilder {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	sb.b.AddOutput(output)
+	return sb
+}
+
+// Update calls the given function with the wrapped TransactionBuilder while holding the builder's mutex,
+// in order to use the builder functions which are not wrapped by the SynchronizedTransactionBuilder.
+// The TransactionBuilder must not be retained beyond the call.
+func (sb *SynchronizedTransactionBuilder) Update(f func(b *TransactionBuilder)) *SynchronizedTransactionBuilder {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	f(sb.b)
+	return sb
+}
+
+// Snapshot returns a TransactionBuilder holding a copy of the builder's current state. See TransactionBuilder.Clone.
+func (sb *SynchronizedTransactionBuilder) Snapshot() *TransactionBuilder {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return sb.b.Clone()
+}
+
+// Build builds the Transaction out of a snapshot of the builder's current state. See TransactionBuilder.Build.
+func (sb *SynchronizedTransactionBuilder) Build(signer AddressSigner) (*Transaction, error) {
+	return sb.Snapshot().Build(signer)
+}
@@ -0,0 +1,87 @@
+package iotago_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestSynchronizedTransactionBuilder(t *testing.T) {
+	defer gock.Off()
+
+	identity := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identity.Public().(ed25519.PublicKey))
+	signer := iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &inputAddr, Keys: identity})
+	outputAddr, _ := tpkg.RandEd25519Address()
+
+	queriedUTXO, _ := tpkg.RandUTXOInput()
+	mockNodeAddressOutputs(t, &inputAddr, map[*iotago.UTXOInput]iotago.Output{
+		queriedUTXO: &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 1000},
+	})
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+
+	const amountInputs = 20
+	builder := iotago.NewSynchronizedTransactionBuilder()
+
+	var wg sync.WaitGroup
+	wg.Add(amountInputs + 2)
+	for i := 0; i < amountInputs; i++ {
+		go func() {
+			defer wg.Done()
+			utxoInput, _ := tpkg.RandUTXOInput()
+			builder.AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: utxoInput, Output: &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 1000}})
+		}()
+	}
+	go func() {
+		defer wg.Done()
+		builder.AddInputsViaNodeQuery(context.Background(), &inputAddr, nodeAPI, nil)
+	}()
+	go func() {
+		defer wg.Done()
+		builder.AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: (amountInputs + 1) * 1000})
+	}()
+	wg.Wait()
+	require.NoError(t, builder.Err())
+
+	tx, err := builder.Build(signer)
+	require.NoError(t, err)
+	require.Len(t, tx.Essence.(*iotago.TransactionEssence).Inputs, amountInputs+1)
+
+	// building works on a snapshot and leaves the builder's state untouched
+	builder.Update(func(b *iotago.TransactionBuilder) { b.AddRemainderOutput(&inputAddr) })
+	snapshot := builder.Snapshot()
+	_, err = builder.Build(signer)
+	require.NoError(t, err)
+	_, err = snapshot.Build(signer)
+	require.NoError(t, err)
+}
+
+func TestSynchronizedTransactionBuilder_AddInputsViaNodeQueryInputsLimit(t *testing.T) {
+	defer gock.Off()
+
+	inputAddr, _ := tpkg.RandEd25519Address()
+	outputs := map[*iotago.UTXOInput]iotago.Output{}
+	for i := 0; i < 3; i++ {
+		utxoInput, _ := tpkg.RandUTXOInput()
+		outputs[utxoInput] = &iotago.SigLockedSingleOutput{Address: inputAddr, Amount: uint64(1000 * (i + 1))}
+	}
+	mockNodeAddressOutputs(t, inputAddr, outputs)
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+
+	builder := iotago.NewSynchronizedTransactionBuilder().
+		Update(func(b *iotago.TransactionBuilder) { b.WithNodeQueryInputsLimit(2, true) }).
+		AddInputsViaNodeQuery(context.Background(), inputAddr, nodeAPI, nil)
+	require.NoError(t, builder.Err())
+
+	snapshot := builder.Snapshot()
+	inputSum, err := snapshot.InputSum()
+	require.NoError(t, err)
+	require.EqualValues(t, 5000, inputSum)
+	require.Equal(t, 1, snapshot.NodeQuerySkippedInputs())
+}
@@ -0,0 +1,84 @@
+package iotago
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNoTipsSelected gets returned when a TipSelector did not select any tips.
+	ErrNoTipsSelected = errors.New("no tips selected")
+)
+
+// TipSelector selects the messages a new message references as its parents.
+type TipSelector interface {
+	// SelectTips returns the IDs of the messages to use as parents.
+	SelectTips(ctx context.Context) (MessageIDs, error)
+}
+
+// TipSelectorFunc implements the TipSelector interface.
+type TipSelectorFunc func(ctx context.Context) (MessageIDs, error)
+
+func (f TipSelectorFunc) SelectTips(ctx context.Context) (MessageIDs, error) {
+	return f(ctx)
+}
+
+// NewNodeTipSelector returns a TipSelector which selects the tips returned by the tips endpoint of the given node.
+func NewNodeTipSelector(nodeAPI *NodeHTTPAPIClient) TipSelector {
+	return TipSelectorFunc(func(ctx context.Context) (MessageIDs, error) {
+		res, err := nodeAPI.Tips(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch tips from node API: %w", err)
+		}
+
+		tips, err := res.Tips()
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch tips: %w", err)
+		}
+		if len(tips) == 0 {
+			return nil, fmt.Errorf("%w: node %s returned no tips", ErrNoTipsSelected, nodeAPI.BaseURL)
+		}
+		return tips, nil
+	})
+}
+
+// NewWeightedNodeTipSelector returns a TipSelector which queries the tips endpoint of the given node and the metadata
+// of every returned tip in order to prefer non-lazy tips: tips which the node marks to be promoted or reattached
+// are only selected if none of the tips is non-lazy, as referencing lazy tips decreases the chance of a message
+// to get confirmed. Tips whose metadata can not be queried are treated as lazy.
+func NewWeightedNodeTipSelector(nodeAPI *NodeHTTPAPIClient) TipSelector {
+	nodeTipSelector := NewNodeTipSelector(nodeAPI)
+	return TipSelectorFunc(func(ctx context.Context) (MessageIDs, error) {
+		tips, err := nodeTipSelector.SelectTips(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		nonLazyTips := make(MessageIDs, 0, len(tips))
+		for _, tip := range tips {
+			metadata, err := nodeAPI.MessageMetadataByMessageID(ctx, tip)
+			if err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return nil, ctxErr
+				}
+				continue
+			}
+			if isLazyTip(metadata) {
+				continue
+			}
+			nonLazyTips = append(nonLazyTips, tip)
+		}
+
+		if len(nonLazyTips) == 0 {
+			return tips, nil
+		}
+		return nonLazyTips, nil
+	})
+}
+
+// tells whether the node considers the message of the given metadata to be a lazy tip.
+func isLazyTip(metadata *MessageMetadataResponse) bool {
+	return (metadata.ShouldPromote != nil && *metadata.ShouldPromote) ||
+		(metadata.ShouldReattach != nil && *metadata.ShouldReattach)
+}
@@ -0,0 +1,84 @@
+package iotago_test
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func mockNodeTips(tips iotago.MessageIDs) {
+	res := &iotago.NodeTipsResponse{TipsHex: []string{}}
+	for _, tip := range tips {
+		res.TipsHex = append(res.TipsHex, hex.EncodeToString(tip[:]))
+	}
+
+	gock.New(nodeAPIUrl).
+		Get(iotago.NodeAPIRouteTips).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: res})
+}
+
+func mockNodeTipMetadata(tip iotago.MessageID, lazy bool) {
+	tipHex := hex.EncodeToString(tip[:])
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteMessageMetadata, tipHex)).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.MessageMetadataResponse{
+			MessageID:      tipHex,
+			Solid:          true,
+			ShouldPromote:  &lazy,
+			ShouldReattach: &lazy,
+		}})
+}
+
+func TestNodeTipSelector(t *testing.T) {
+	defer gock.Off()
+
+	tips := tpkg.SortedRand32BytArray(2)
+	mockNodeTips(tips)
+
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+	selected, err := iotago.NewNodeTipSelector(nodeAPI).SelectTips(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, tips, selected)
+
+	mockNodeTips(nil)
+	_, err = iotago.NewNodeTipSelector(nodeAPI).SelectTips(context.Background())
+	require.True(t, errors.Is(err, iotago.ErrNoTipsSelected))
+}
+
+func TestWeightedNodeTipSelector(t *testing.T) {
+	defer gock.Off()
+
+	tips := tpkg.SortedRand32BytArray(3)
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+
+	// lazy tips are dropped
+	mockNodeTips(tips)
+	mockNodeTipMetadata(tips[0], true)
+	mockNodeTipMetadata(tips[1], false)
+	mockNodeTipMetadata(tips[2], false)
+
+	selected, err := iotago.NewWeightedNodeTipSelector(nodeAPI).SelectTips(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, iotago.MessageIDs{tips[1], tips[2]}, selected)
+	require.True(t, gock.IsDone())
+
+	// but used if there are no other tips
+	mockNodeTips(tips)
+	for _, tip := range tips {
+		mockNodeTipMetadata(tip, true)
+	}
+
+	selected, err = iotago.NewWeightedNodeTipSelector(nodeAPI).SelectTips(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, tips, selected)
+	require.True(t, gock.IsDone())
+}
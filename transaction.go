@@ -69,6 +69,104 @@ func (t *Transaction) ID() (*TransactionID, error) {
 	return &h, nil
 }
 
+// OutputIDs computes the UTXOInputIDs identifying the outputs created by the Transaction,
+// in the order of the outputs within its essence.
+func (t *Transaction) OutputIDs() (UTXOInputIDs, error) {
+	txEssence, ok := t.Essence.(*TransactionEssence)
+	if !ok {
+		return nil, fmt.Errorf("%w: transaction essence is not *TransactionEssence", ErrInvalidTransactionEssence)
+	}
+
+	txID, err := t.ID()
+	if err != nil {
+		return nil, err
+	}
+
+	outputIDs := make(UTXOInputIDs, len(txEssence.Outputs))
+	for i := range txEssence.Outputs {
+		outputIDs[i] = (&UTXOInput{TransactionID: *txID, TransactionOutputIndex: uint16(i)}).ID()
+	}
+	return outputIDs, nil
+}
+
+// ByteRange is a range of byte offsets [Start, End) within serialized data.
+type ByteRange struct {
+	Start int
+	End   int
+}
+
+// ByteOffsets maps the names of serialized fields to the ByteRange they occupy within the serialized data.
+// Nested fields are joined by dots, elements of lists are named by the list and their index, e.g. "essence.inputs[0]".
+type ByteOffsets map[string]ByteRange
+
+// SerializeWithOffsets serializes the Transaction like Serialize and additionally returns the ByteOffsets of
+// the payload type, the essence and its type, inputs, outputs and payload as well as of the unlock blocks.
+// The ranges of lists include their length prefix and the range of the essence's payload excludes its length prefix.
+func (t *Transaction) SerializeWithOffsets(deSeriMode serializer.DeSerializationMode) ([]byte, ByteOffsets, error) {
+	txEssence, ok := t.Essence.(*TransactionEssence)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: transaction essence is not *TransactionEssence", ErrInvalidTransactionEssence)
+	}
+
+	data, err := t.Serialize(deSeriMode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	offsets := ByteOffsets{}
+	var pos int
+	field := func(name string, size int) {
+		offsets[name] = ByteRange{Start: pos, End: pos + size}
+		pos += size
+	}
+	object := func(name string, seri serializer.Serializable) error {
+		// any sorting already happened within Serialize
+		seriData, err := seri.Serialize(serializer.DeSeriModeNoValidation)
+		if err != nil {
+			return fmt.Errorf("unable to serialize %s: %w", name, err)
+		}
+		field(name, len(seriData))
+		return nil
+	}
+	list := func(name string, seris serializer.Serializables) error {
+		start := pos
+		pos += serializer.UInt16ByteSize
+		for i, seri := range seris {
+			if err := object(fmt.Sprintf("%s[%d]", name, i), seri); err != nil {
+				return err
+			}
+		}
+		offsets[name] = ByteRange{Start: start, End: pos}
+		return nil
+	}
+
+	field("payloadType", serializer.TypeDenotationByteSize)
+	essenceStart := pos
+	field("essence.type", serializer.SmallTypeDenotationByteSize)
+	if err := list("essence.inputs", txEssence.Inputs); err != nil {
+		return nil, nil, err
+	}
+	if err := list("essence.outputs", txEssence.Outputs); err != nil {
+		return nil, nil, err
+	}
+	pos += serializer.PayloadLengthByteSize
+	if txEssence.Payload != nil {
+		if err := object("essence.payload", txEssence.Payload); err != nil {
+			return nil, nil, err
+		}
+	}
+	offsets["essence"] = ByteRange{Start: essenceStart, End: pos}
+	if err := list("unlockBlocks", t.UnlockBlocks); err != nil {
+		return nil, nil, err
+	}
+
+	if pos != len(data) {
+		return nil, nil, fmt.Errorf("byte offsets end at %d but the serialized transaction has a size of %d", pos, len(data))
+	}
+
+	return data, offsets, nil
+}
+
 func (t *Transaction) Deserialize(data []byte, deSeriMode serializer.DeSerializationMode) (int, error) {
 	unlockBlockArrayRules := &serializer.ArrayRules{}
 
@@ -162,6 +260,25 @@ func (t *Transaction) UnmarshalJSON(bytes []byte) error {
 	return nil
 }
 
+// MarshalCBOR encodes the Transaction as CBOR mirroring the structure of its JSON encoding, hence binary fields are
+// hex encoded text strings. The encoding is deterministic but not canonical CBOR, see jsonToCBOR.
+func (t *Transaction) MarshalCBOR() ([]byte, error) {
+	jsonData, err := t.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return jsonToCBOR(jsonData)
+}
+
+// UnmarshalCBOR decodes the Transaction from CBOR produced by MarshalCBOR.
+func (t *Transaction) UnmarshalCBOR(data []byte) error {
+	jsonData, err := cborToJSON(data)
+	if err != nil {
+		return err
+	}
+	return t.UnmarshalJSON(jsonData)
+}
+
 // SyntacticallyValidate syntactically validates the Transaction:
 //	1. The TransactionEssence isn't nil
 //	2. syntactic validation on the TransactionEssence
@@ -1,18 +1,119 @@
 package iotago
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/iota.go/v2/units"
+	"golang.org/x/crypto/blake2b"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 )
 
 var (
+	// ErrTransactionBuilder defines a generic error occurring within the TransactionBuilder.
+	ErrTransactionBuilder = errors.New("transaction builder error")
 	// ErrTransactionBuilderUnsupportedAddress gets returned when an unsupported address type
 	// is given for a builder operation.
-	ErrTransactionBuilderUnsupportedAddress = errors.New("unsupported address type")
+	ErrTransactionBuilderUnsupportedAddress = fmt.Errorf("%w: unsupported address type", ErrTransactionBuilder)
+	// ErrTransactionBuilderDuplicatedInput gets returned when an input referencing an already added UTXO is added.
+	ErrTransactionBuilderDuplicatedInput = fmt.Errorf("%w: duplicated input", ErrTransactionBuilder)
+	// ErrTransactionBuilderNoSigner gets returned when no AddressSigner is available for the address of an input.
+	ErrTransactionBuilderNoSigner = fmt.Errorf("%w: no signer available", ErrTransactionBuilder)
+	// ErrTransactionBuilderTooManyInputs gets returned when the transaction would exceed MaxInputsCount.
+	ErrTransactionBuilderTooManyInputs = fmt.Errorf("%w: too many inputs", ErrTransactionBuilder)
+	// ErrTransactionBuilderTooManyOutputs gets returned when the transaction would exceed MaxOutputsCount.
+	ErrTransactionBuilderTooManyOutputs = fmt.Errorf("%w: too many outputs", ErrTransactionBuilder)
+	// ErrTransactionBuilderInsufficientInputs gets returned when the outputs deposit more than the inputs.
+	ErrTransactionBuilderInsufficientInputs = fmt.Errorf("%w: insufficient inputs", ErrTransactionBuilder)
+	// ErrTransactionBuilderDustViolation gets returned when the transaction would violate the dust protection rules.
+	ErrTransactionBuilderDustViolation = fmt.Errorf("%w: dust protection violation", ErrTransactionBuilder)
+	// ErrTransactionBuilderStorageDepositNotCovered gets returned when outputs deposit less than their minimum storage deposit.
+	ErrTransactionBuilderStorageDepositNotCovered = fmt.Errorf("%w: storage deposit not covered", ErrTransactionBuilder)
+	// ErrTransactionBuilderUnlockBlocksMismatch gets returned when the unlock blocks do not correspond to the inputs.
+	ErrTransactionBuilderUnlockBlocksMismatch = fmt.Errorf("%w: unlock blocks mismatch", ErrTransactionBuilder)
+	// ErrTransactionBuilderExceedsTokenSupply gets returned when an amount exceeds the total supply of tokens.
+	ErrTransactionBuilderExceedsTokenSupply = fmt.Errorf("%w: exceeds the token supply", ErrTransactionBuilder)
+	// ErrTransactionBuilderZeroDepositOutput gets returned when an output deposits zero tokens.
+	ErrTransactionBuilderZeroDepositOutput = fmt.Errorf("%w: zero deposit output", ErrTransactionBuilder)
+	// ErrTransactionBuilderInvalidEssencePayload gets returned when the essence holds a payload which is not an Indexation.
+	ErrTransactionBuilderInvalidEssencePayload = fmt.Errorf("%w: invalid essence payload", ErrTransactionBuilder)
+	// ErrTransactionBuilderInvalidSignature gets returned when a produced signature does not verify.
+	ErrTransactionBuilderInvalidSignature = fmt.Errorf("%w: invalid signature", ErrTransactionBuilder)
+	// ErrTransactionBuilderNoInputs gets returned when no inputs were added to the builder.
+	// It matches both ErrTransactionBuilder and ErrMinInputsNotReached, as the transaction would fail its validation.
+	ErrTransactionBuilderNoInputs error = &transactionBuilderValidationError{validationErr: ErrMinInputsNotReached, msg: "no inputs added to the transaction builder"}
+	// ErrTransactionBuilderNoOutputs gets returned when neither outputs nor a remainder output were added to the builder.
+	// It matches both ErrTransactionBuilder and ErrMinOutputsNotReached, as the transaction would fail its validation.
+	ErrTransactionBuilderNoOutputs error = &transactionBuilderValidationError{validationErr: ErrMinOutputsNotReached, msg: "no outputs added to the transaction builder"}
 )
 
+// transactionBuilderValidationError is an ErrTransactionBuilder which wraps the validation error
+// the built transaction would fail with.
+type transactionBuilderValidationError struct {
+	validationErr error
+	msg           string
+}
+
+func (e *transactionBuilderValidationError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", ErrTransactionBuilder, e.validationErr, e.msg)
+}
+
+func (e *transactionBuilderValidationError) Unwrap() error {
+	return e.validationErr
+}
+
+func (e *transactionBuilderValidationError) Is(target error) bool {
+	return target == ErrTransactionBuilder
+}
+
+// TransactionBuilderSortMode defines how the TransactionBuilder orders the inputs and outputs of the essence.
+type TransactionBuilderSortMode byte
+
+const (
+	// TransactionBuilderSortModeLexical sorts the inputs and outputs by their serialized lexical representation
+	// as required by the protocol. This is the default.
+	TransactionBuilderSortModeLexical TransactionBuilderSortMode = iota
+	// TransactionBuilderSortModeNone keeps the inputs and outputs in the order they were added to the builder.
+	// Essences built with this mode are not validated and fail serialization/deserialization with
+	// DeSeriModePerformValidation unless they were already added in lexical order.
+	// This mode is only meant for producing invalid transactions for testing purposes.
+	TransactionBuilderSortModeNone
+)
+
+// TransactionBuilderRemainderMode defines how the TransactionBuilder deposits the remainder added via AddRemainderOutput.
+type TransactionBuilderRemainderMode byte
+
+const (
+	// TransactionBuilderRemainderModeSingleOutput deposits any remainder as a SigLockedSingleOutput. This is the default.
+	TransactionBuilderRemainderModeSingleOutput TransactionBuilderRemainderMode = iota
+	// TransactionBuilderRemainderModeErrorIfDust lets Build fail if the remainder is below
+	// OutputSigLockedDustAllowanceOutputMinDeposit, as such a dust output is only accepted by the network
+	// if the remainder address holds enough dust allowance.
+	TransactionBuilderRemainderModeErrorIfDust
+)
+
+// TransactionBuilderInputSelectionStrategy defines in which order SelectInputs picks the unspent outputs of an address.
+type TransactionBuilderInputSelectionStrategy byte
+
+const (
+	// TransactionBuilderInputSelectionLargestFirst picks the outputs with the largest deposits first,
+	// which minimizes the amount of inputs and therefore the size of the transaction.
+	TransactionBuilderInputSelectionLargestFirst TransactionBuilderInputSelectionStrategy = iota
+	// TransactionBuilderInputSelectionSmallestFirst picks the outputs with the smallest deposits first,
+	// which consolidates small outputs and therefore counters the fragmentation of the address' funds.
+	TransactionBuilderInputSelectionSmallestFirst
+)
+
+// DefaultNodeQueryTimeout is the timeout applied to node queries of the TransactionBuilder
+// if the given context has no deadline. See WithNodeQueryTimeout.
+const DefaultNodeQueryTimeout = 30 * time.Second
+
 // NewTransactionBuilder creates a new TransactionBuilder.
 func NewTransactionBuilder() *TransactionBuilder {
 	return &TransactionBuilder{
@@ -21,8 +122,47 @@ func NewTransactionBuilder() *TransactionBuilder {
 			Outputs: serializer.Serializables{},
 			Payload: nil,
 		},
-		inputToAddr: map[UTXOInputID]Address{},
+		inputToAddr:      map[UTXOInputID]Address{},
+		inputToOutput:    InputToOutputMapping{},
+		addrSigners:      map[string]AddressSigner{},
+		outputTags:       map[Output]interface{}{},
+		nodeQueryTimeout: DefaultNodeQueryTimeout,
+	}
+}
+
+// NewTransactionBuilderFromTransaction creates a new TransactionBuilder populated with the inputs, outputs and payload
+// of the given Transaction, so that the transaction can be modified and signed again. The unlock blocks of the given
+// Transaction are discarded as any modification invalidates them. inputToAddr must contain the address of every input.
+// The given Transaction itself is not modified.
+func NewTransactionBuilderFromTransaction(tx *Transaction, inputToAddr map[UTXOInputID]Address) *TransactionBuilder {
+	b := NewTransactionBuilder()
+
+	txEssence, ok := tx.Essence.(*TransactionEssence)
+	if !ok {
+		b.occurredBuildErr = fmt.Errorf("%w: transaction essence is not *TransactionEssence", ErrTransactionBuilder)
+		return b
+	}
+
+	for i, input := range txEssence.Inputs {
+		utxoInput, ok := input.(*UTXOInput)
+		if !ok {
+			b.occurredBuildErr = fmt.Errorf("%w: input at index %d is not an UTXO input", ErrTransactionBuilder, i)
+			return b
+		}
+		addr, has := inputToAddr[utxoInput.ID()]
+		if !has {
+			b.occurredBuildErr = fmt.Errorf("%w: no address given for input %s at index %d", ErrTransactionBuilder, utxoInput.ID().ToHex(), i)
+			return b
+		}
+		b.AddInput(&ToBeSignedUTXOInput{Address: addr, Input: utxoInput})
 	}
+
+	for _, output := range txEssence.Outputs {
+		b.AddOutput(output.(Output))
+	}
+	b.essence.Payload = txEssence.Payload
+
+	return b
 }
 
 // TransactionBuilder is used to easily build up a Transaction.
@@ -30,6 +170,23 @@ type TransactionBuilder struct {
 	occurredBuildErr error
 	essence          *TransactionEssence
 	inputToAddr      map[UTXOInputID]Address
+	inputToOutput    InputToOutputMapping
+	treasuryInput    *TreasuryInput
+	treasuryOutput   *TreasuryOutput
+	sortMode         TransactionBuilderSortMode
+	remainderMode    TransactionBuilderRemainderMode
+	remainderAddr    Address
+	remainderOutput  Output
+	rentStruct       *RentStructure
+	minOutputAmount  uint64
+	verifySigs       bool
+	addrSigners      map[string]AddressSigner
+	outputTags       map[Output]interface{}
+	// settings of the node queries
+	nodeQueryTimeout         time.Duration
+	nodeQueryMaxInputs       int
+	nodeQueryPreferHighValue bool
+	nodeQuerySkippedInputs   int
 }
 
 // ToBeSignedUTXOInput defines a UTXO input which needs to be signed.
@@ -38,117 +195,1514 @@ type ToBeSignedUTXOInput struct {
 	Address Address `json:"address"`
 	// The actual UTXO input.
 	Input *UTXOInput `json:"input"`
+	// The output referenced by the UTXO input. Optional, but required
+	// for operations which need to know the value of the input.
+	Output Output `json:"output,omitempty"`
+}
+
+// Err returns the error which occurred during the previous builder steps or nil if none occurred.
+func (b *TransactionBuilder) Err() error {
+	return b.occurredBuildErr
+}
+
+// Reset resets the builder to the state of a newly created TransactionBuilder, so that it can be reused
+// to build another transaction. Transactions previously built by the builder are not affected.
+func (b *TransactionBuilder) Reset() *TransactionBuilder {
+	*b = *NewTransactionBuilder()
+	return b
+}
+
+// Clone returns a copy of the builder. The slices of inputs and outputs and the builder's internal mappings are copied,
+// so that adding to or removing from the clone does not affect the original builder and vice versa. However, the added inputs,
+// outputs, addresses, signers and the payload themselves are shared between the clone and the original builder.
+func (b *TransactionBuilder) Clone() *TransactionBuilder {
+	clone := *b
+	clone.essence = &TransactionEssence{
+		Inputs:  make(serializer.Serializables, len(b.essence.Inputs)),
+		Outputs: make(serializer.Serializables, len(b.essence.Outputs)),
+		Payload: b.essence.Payload,
+	}
+	copy(clone.essence.Inputs, b.essence.Inputs)
+	copy(clone.essence.Outputs, b.essence.Outputs)
+
+	clone.inputToAddr = make(map[UTXOInputID]Address, len(b.inputToAddr))
+	for k, v := range b.inputToAddr {
+		clone.inputToAddr[k] = v
+	}
+
+	clone.inputToOutput = make(InputToOutputMapping, len(b.inputToOutput))
+	for k, v := range b.inputToOutput {
+		clone.inputToOutput[k] = v
+	}
+
+	clone.addrSigners = make(map[string]AddressSigner, len(b.addrSigners))
+	for k, v := range b.addrSigners {
+		clone.addrSigners[k] = v
+	}
+
+	clone.outputTags = make(map[Output]interface{}, len(b.outputTags))
+	for k, v := range b.outputTags {
+		clone.outputTags[k] = v
+	}
+
+	return &clone
+}
+
+// String returns a human readable dump of the builder's current state for debugging purposes,
+// rendering addresses as bech32 strings of the mainnet. See StringWithHRP.
+func (b *TransactionBuilder) String() string {
+	return b.StringWithHRP(PrefixMainnet)
+}
+
+// StringWithHRP returns a human readable dump of the builder's current state for debugging purposes,
+// rendering addresses as bech32 strings with the given human readable part.
+// Inputs and outputs are listed in lexical order, so that the dump is deterministic.
+func (b *TransactionBuilder) StringWithHRP(hrp NetworkPrefix) string {
+	addrStr := func(addr serializer.Serializable) string {
+		if addr, ok := addr.(Address); ok {
+			return addr.Bech32(hrp)
+		}
+		return "none"
+	}
+
+	inputLines := make([]string, 0, len(b.essence.Inputs))
+	for _, input := range b.essence.Inputs {
+		utxoID := input.(*UTXOInput).ID()
+		inputLines = append(inputLines, fmt.Sprintf("\t%s address=%s", utxoID.ToHex(), addrStr(b.inputToAddr[utxoID])))
+	}
+	sort.Strings(inputLines)
+
+	outputLines := make([]string, 0, len(b.essence.Outputs))
+	for _, output := range b.essence.Outputs {
+		out := output.(Output)
+		target, _ := out.Target()
+		deposit, _ := out.Deposit()
+		outputLines = append(outputLines, fmt.Sprintf("\t%T address=%s amount=%d", out, addrStr(target), deposit))
+	}
+	sort.Strings(outputLines)
+
+	payloadStr := "none"
+	if b.essence.Payload != nil {
+		payloadStr = fmt.Sprintf("%T", b.essence.Payload)
+	}
+
+	errStr := "none"
+	if b.occurredBuildErr != nil {
+		errStr = b.occurredBuildErr.Error()
+	}
+
+	var sb strings.Builder
+	sb.WriteString("TransactionBuilder{\n")
+	fmt.Fprintf(&sb, "inputs (%d):\n", len(inputLines))
+	for _, line := range inputLines {
+		sb.WriteString(line + "\n")
+	}
+	fmt.Fprintf(&sb, "outputs (%d):\n", len(outputLines))
+	for _, line := range outputLines {
+		sb.WriteString(line + "\n")
+	}
+	fmt.Fprintf(&sb, "payload: %s\nerror: %s\n}", payloadStr, errStr)
+	return sb.String()
+}
+
+// WithInputsOutputsSortMode sets the TransactionBuilderSortMode used by Build.
+func (b *TransactionBuilder) WithInputsOutputsSortMode(sortMode TransactionBuilderSortMode) *TransactionBuilder {
+	b.sortMode = sortMode
+	return b
+}
+
+// IsEssenceSorted reports whether the added inputs and outputs are already in the lexical order of their serialized form,
+// in which case building the transaction with TransactionBuilderSortModeLexical does not reorder them.
+// The added inputs and outputs are not modified. Note that a remainder output is only added on Build.
+func (b *TransactionBuilder) IsEssenceSorted() (bool, error) {
+	inputsSorted, err := isLexicallySorted(b.essence.Inputs)
+	if err != nil {
+		return false, fmt.Errorf("unable to check order of inputs: %w", err)
+	}
+	outputsSorted, err := isLexicallySorted(b.essence.Outputs)
+	if err != nil {
+		return false, fmt.Errorf("unable to check order of outputs: %w", err)
+	}
+	return inputsSorted && outputsSorted, nil
+}
+
+// checks whether the given serializables are in the lexical order of their serialized form.
+func isLexicallySorted(seris serializer.Serializables) (bool, error) {
+	var prev []byte
+	for i, seri := range seris {
+		data, err := seri.Serialize(serializer.DeSeriModeNoValidation)
+		if err != nil {
+			return false, fmt.Errorf("unable to serialize element at index %d: %w", i, err)
+		}
+		if i > 0 && bytes.Compare(prev, data) > 0 {
+			return false, nil
+		}
+		prev = data
+	}
+	return true, nil
+}
+
+// WithPostSignVerification lets Build verify every produced signature against the signing message and the address
+// of the inputs it unlocks, so that a faulty AddressSigner results in an error instead of an invalid transaction.
+// BuildWithUnlockBlocks likewise verifies the signatures of the given unlock blocks.
+func (b *TransactionBuilder) WithPostSignVerification() *TransactionBuilder {
+	b.verifySigs = true
+	return b
+}
+
+// WithMinOutputAmount lets Build verify that every SigLockedSingleOutput deposits at least the given threshold,
+// i.e. OutputSigLockedDustAllowanceOutputMinDeposit, unless it is covered by a SigLockedDustAllowanceOutput
+// of the transaction depositing onto the same address: every DustAllowanceDivisor tokens of dust allowance
+// cover one output below the threshold. Build fails with an error wrapping ErrTransactionBuilderDustViolation
+// and listing the offending outputs otherwise. Dust allowance already residing on the addresses is not taken
+// into account, use BuildWithDustValidation for that. A threshold of zero disables the check, which is the default.
+func (b *TransactionBuilder) WithMinOutputAmount(threshold uint64) *TransactionBuilder {
+	b.minOutputAmount = threshold
+	return b
+}
+
+// WithRentStructure lets Build verify that every output deposits at least its minimum storage deposit
+// under the given RentStructure. Passing nil disables the check, which is the default.
+func (b *TransactionBuilder) WithRentStructure(rentStruct *RentStructure) *TransactionBuilder {
+	b.rentStruct = rentStruct
+	return b
+}
+
+// WithSignerForAddress registers the given AddressSigner to be used by Build for inputs belonging to the given address.
+// Inputs of addresses without a registered AddressSigner are signed by the AddressSigner passed to Build.
+func (b *TransactionBuilder) WithSignerForAddress(addr Address, signer AddressSigner) *TransactionBuilder {
+	addrKey, err := addressKey(addr)
+	if err != nil {
+		b.occurredBuildErr = fmt.Errorf("%w: %s", ErrTransactionBuilder, err)
+		return b
+	}
+	b.addrSigners[addrKey] = signer
+	return b
 }
 
 // AddInput adds the given input to the builder.
+// Adding an input referencing the same UTXO as a previously added input sets an error.
 func (b *TransactionBuilder) AddInput(input *ToBeSignedUTXOInput) *TransactionBuilder {
-	b.inputToAddr[input.Input.ID()] = input.Address
+	utxoID := input.Input.ID()
+	if _, has := b.inputToAddr[utxoID]; has {
+		b.occurredBuildErr = fmt.Errorf("%w: input %s was already added", ErrTransactionBuilderDuplicatedInput, utxoID.ToHex())
+		return b
+	}
+	b.inputToAddr[utxoID] = input.Address
+	if input.Output != nil {
+		b.inputToOutput[utxoID] = input.Output
+	}
 	b.essence.Inputs = append(b.essence.Inputs, input.Input)
 	return b
 }
 
+// AddInputs adds the given inputs to the builder in the given order.
+func (b *TransactionBuilder) AddInputs(inputs ...*ToBeSignedUTXOInput) *TransactionBuilder {
+	for _, input := range inputs {
+		b.AddInput(input)
+	}
+	return b
+}
+
+// WithNodeQueryTimeout sets the timeout applied to AddInputsViaNodeQuery, AddInputsViaNodeQueryWithMetadata and SelectInputs
+// if the context passed to them has no deadline. A context with a deadline is always respected as is.
+// A timeout of zero disables the default timeout. Defaults to DefaultNodeQueryTimeout.
+func (b *TransactionBuilder) WithNodeQueryTimeout(timeout time.Duration) *TransactionBuilder {
+	b.nodeQueryTimeout = timeout
+	return b
+}
+
+// returns a context bound by the node query timeout if the given context has no deadline.
+func (b *TransactionBuilder) nodeQueryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, hasDeadline := ctx.Deadline(); hasDeadline || b.nodeQueryTimeout == 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, b.nodeQueryTimeout)
+}
+
+// WithNodeQueryInputsLimit limits AddInputsViaNodeQuery and AddInputsViaNodeQueryWithMetadata to only add
+// inputs as long as the builder holds less than maxInputs inputs. If preferHighValue is true, the queried outputs
+// with the highest deposits are added first, which minimizes the amount of inputs needed to fund the outputs.
+// The outputs which passed the filter but were not added because of the limit are counted by NodeQuerySkippedInputs.
+// A maxInputs of zero disables the limit, which is the default.
+func (b *TransactionBuilder) WithNodeQueryInputsLimit(maxInputs int, preferHighValue bool) *TransactionBuilder {
+	b.nodeQueryMaxInputs = maxInputs
+	b.nodeQueryPreferHighValue = preferHighValue
+	return b
+}
+
+// NodeQuerySkippedInputs returns the amount of outputs which were not added as inputs by node queries
+// because of the limit set via WithNodeQueryInputsLimit. Callers can use it to decide whether to consolidate
+// the outputs of an address first (see BuildConsolidation).
+func (b *TransactionBuilder) NodeQuerySkippedInputs() int {
+	return b.nodeQuerySkippedInputs
+}
+
 // TransactionBuilderInputFilter is a filter function which determines whether
 // an input should be used or not. (returning true = pass). The filter can also
 // be used to accumulate data over the set of inputs, i.e. the input sum etc.
 type TransactionBuilderInputFilter func(utxoInput *UTXOInput, input Output) bool
 
 // AddInputsViaNodeQuery adds any unspent outputs by the given address as an input to the built transaction
-// if it passes the filter function. It is the caller's job to ensure that the limit of returned outputs on the queried
-// node is enough high for the application's purpose. filter can be nil.
+// if it passes the filter function. Outputs which the node reports as spent by the time they are queried are skipped.
+// The node API does not support paging through the outputs of an address, therefore an error is set if the queried
+// node returned its maximum amount of results, as the outputs are then likely incomplete. filter can be nil.
 func (b *TransactionBuilder) AddInputsViaNodeQuery(ctx context.Context, addr Address, nodeHTTPAPIClient *NodeHTTPAPIClient, filter TransactionBuilderInputFilter) *TransactionBuilder {
-	switch x := addr.(type) {
-	case *Ed25519Address:
-	default:
-		b.occurredBuildErr = fmt.Errorf("%w: auto. inputs via node query only supports Ed25519Address but got %T", ErrTransactionBuilderUnsupportedAddress, x)
-	}
+	ctx, cancel := b.nodeQueryContext(ctx)
+	defer cancel()
 
-	_, unspentOutputs, err := nodeHTTPAPIClient.OutputsByEd25519Address(ctx, addr.(*Ed25519Address), false)
+	unspentOutputs, err := unspentOutputsByAddress(ctx, addr, nodeHTTPAPIClient)
 	if err != nil {
 		b.occurredBuildErr = err
 		return b
 	}
 
+	return b.addUnspentOutputsAsInputs(ctx, addr, unspentOutputs, filter)
+}
+
+// AddInputsViaMultiNodeQuery works like AddInputsViaNodeQuery but queries the unspent outputs of the given address
+// via the given MultiNodeClient, failing over to the next node if the query fails on a node.
+func (b *TransactionBuilder) AddInputsViaMultiNodeQuery(ctx context.Context, addr Address, multiNodeClient *MultiNodeClient, filter TransactionBuilderInputFilter) *TransactionBuilder {
+	ctx, cancel := b.nodeQueryContext(ctx)
+	defer cancel()
+
+	var unspentOutputs map[*UTXOInput]Output
+	if err := multiNodeClient.Do(ctx, func(ctx context.Context, node *NodeHTTPAPIClient) error {
+		var err error
+		unspentOutputs, err = unspentOutputsByAddress(ctx, addr, node)
+		return err
+	}); err != nil {
+		b.occurredBuildErr = err
+		return b
+	}
+
+	return b.addUnspentOutputsAsInputs(ctx, addr, unspentOutputs, filter)
+}
+
+// AddInputsViaNodeQueryMulti works like AddInputsViaNodeQuery but queries the unspent outputs of all given addresses
+// concurrently, running at most maxConcurrency queries at once. A maxConcurrency of zero or less queries all addresses
+// at once. The filter is never called concurrently. If a limit was set via WithNodeQueryInputsLimit without preferring
+// high value inputs, the queries stop early once enough outputs passed the filter to reach the limit, hence
+// NodeQuerySkippedInputs only counts the skipped outputs of the addresses queried until then. If the query fails for
+// any address, no inputs are added and the set error lists the failure of every address, wrapping the one of the first
+// failed address.
+func (b *TransactionBuilder) AddInputsViaNodeQueryMulti(ctx context.Context, addrs []Address, nodeHTTPAPIClient *NodeHTTPAPIClient, filter TransactionBuilderInputFilter, maxConcurrency int) *TransactionBuilder {
+	ctx, cancel := b.nodeQueryContext(ctx)
+	defer cancel()
+	queryCtx, stopQueries := context.WithCancel(ctx)
+	defer stopQueries()
+
+	if maxConcurrency <= 0 {
+		maxConcurrency = len(addrs)
+	}
+
+	// the outputs of every address are needed to prefer the ones with the highest deposits
+	free := -1
+	if b.nodeQueryMaxInputs != 0 && !b.nodeQueryPreferHighValue {
+		if free = b.nodeQueryMaxInputs - len(b.essence.Inputs); free < 0 {
+			free = 0
+		}
+	}
+
+	var (
+		mu           sync.Mutex
+		wg           sync.WaitGroup
+		inputs       []*ToBeSignedUTXOInput
+		queryErrs    = make([]error, len(addrs))
+		limitReached = free == 0
+		semaphore    = make(chan struct{}, maxConcurrency)
+	)
+	for i, addr := range addrs {
+		wg.Add(1)
+		go func(i int, addr Address) {
+			defer wg.Done()
+
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-queryCtx.Done():
+			}
+			if err := queryCtx.Err(); err != nil {
+				queryErrs[i] = err
+				return
+			}
+
+			unspentOutputs, err := unspentOutputsByAddress(queryCtx, addr, nodeHTTPAPIClient)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				queryErrs[i] = err
+				return
+			}
+			for utxoInput, output := range unspentOutputs {
+				if filter != nil && !filter(utxoInput, output) {
+					continue
+				}
+				inputs = append(inputs, &ToBeSignedUTXOInput{Address: addr, Input: utxoInput, Output: output})
+			}
+			if free != -1 && len(inputs) >= free {
+				limitReached = true
+				stopQueries()
+			}
+		}(i, addr)
+	}
+	wg.Wait()
+
+	if !limitReached {
+		var firstErr error
+		var failures []string
+		for i, err := range queryErrs {
+			if err == nil {
+				continue
+			}
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%s: %w", addrs[i], err)
+				continue
+			}
+			failures = append(failures, fmt.Sprintf("%s: %s", addrs[i], err))
+		}
+		if firstErr != nil {
+			b.occurredBuildErr = fmt.Errorf("unable to add inputs via node query for %d of %d addresses: %w", len(failures)+1, len(addrs), firstErr)
+			if len(failures) > 0 {
+				b.occurredBuildErr = fmt.Errorf("%w; %s", b.occurredBuildErr, strings.Join(failures, "; "))
+			}
+			return b
+		}
+	}
+
+	return b.addQueriedInputs(inputs)
+}
+
+// adds the given unspent outputs of the given address passing the filter as inputs.
+func (b *TransactionBuilder) addUnspentOutputsAsInputs(ctx context.Context, addr Address, unspentOutputs map[*UTXOInput]Output, filter TransactionBuilderInputFilter) *TransactionBuilder {
+	inputs := make([]*ToBeSignedUTXOInput, 0, len(unspentOutputs))
 	for utxoInput, output := range unspentOutputs {
+		if err := ctx.Err(); err != nil {
+			b.occurredBuildErr = fmt.Errorf("unable to add inputs via node query: %w", err)
+			return b
+		}
+
 		if filter != nil && !filter(utxoInput, output) {
 			continue
 		}
 
-		b.AddInput(&ToBeSignedUTXOInput{Address: addr, Input: utxoInput})
+		inputs = append(inputs, &ToBeSignedUTXOInput{Address: addr, Input: utxoInput, Output: output})
 	}
 
-	return b
+	return b.addQueriedInputs(inputs)
 }
 
-// AddOutput adds the given output to the builder.
-func (b *TransactionBuilder) AddOutput(output Output) *TransactionBuilder {
-	b.essence.Outputs = append(b.essence.Outputs, output)
-	return b
+// adds the given inputs queried from a node while respecting the limit set via WithNodeQueryInputsLimit.
+func (b *TransactionBuilder) addQueriedInputs(inputs []*ToBeSignedUTXOInput) *TransactionBuilder {
+	if b.nodeQueryMaxInputs == 0 {
+		return b.AddInputs(inputs...)
+	}
+
+	if b.nodeQueryPreferHighValue {
+		sort.SliceStable(inputs, func(i, j int) bool {
+			iDeposit, _ := inputs[i].Output.Deposit()
+			jDeposit, _ := inputs[j].Output.Deposit()
+			return iDeposit > jDeposit
+		})
+	}
+
+	free := b.nodeQueryMaxInputs - len(b.essence.Inputs)
+	if free < 0 {
+		free = 0
+	}
+	if len(inputs) > free {
+		b.nodeQuerySkippedInputs += len(inputs) - free
+		inputs = inputs[:free]
+	}
+	return b.AddInputs(inputs...)
 }
 
-// AddIndexationPayload adds the given Indexation as the inner payload.
-func (b *TransactionBuilder) AddIndexationPayload(payload *Indexation) *TransactionBuilder {
-	b.essence.Payload = payload
-	return b
+// SelectInputs queries the unspent outputs of the given address and adds only as many of them as inputs as needed
+// to cover targetAmount, picking the outputs in the order defined by the given strategy. Outputs with equal deposits
+// are picked in the order of their IDs, so that the selection is deterministic. If the selected outputs exceed
+// targetAmount by less than OutputSigLockedDustAllowanceOutputMinDeposit, further outputs are selected until
+// no or a remainder of at least OutputSigLockedDustAllowanceOutputMinDeposit is left, as long as there are any.
+// Outputs already added as inputs to the builder are neither selected again nor count towards targetAmount.
+// Returns the sum of the deposits of the selected outputs. No inputs are added if the unspent outputs can not
+// cover targetAmount or the strategy is unknown.
+func (b *TransactionBuilder) SelectInputs(ctx context.Context, nodeHTTPAPIClient *NodeHTTPAPIClient, addr Address, targetAmount uint64, strategy TransactionBuilderInputSelectionStrategy) (uint64, error) {
+	if b.occurredBuildErr != nil {
+		return 0, b.occurredBuildErr
+	}
+
+	switch strategy {
+	case TransactionBuilderInputSelectionLargestFirst, TransactionBuilderInputSelectionSmallestFirst:
+	default:
+		return 0, fmt.Errorf("%w: unknown input selection strategy %d", ErrTransactionBuilder, strategy)
+	}
+
+	ctx, cancel := b.nodeQueryContext(ctx)
+	defer cancel()
+
+	unspentOutputs, err := unspentOutputsByAddress(ctx, addr, nodeHTTPAPIClient)
+	if err != nil {
+		return 0, err
+	}
+
+	type candidate struct {
+		input   *ToBeSignedUTXOInput
+		id      UTXOInputID
+		deposit uint64
+	}
+	candidates := make([]candidate, 0, len(unspentOutputs))
+	for utxoInput, output := range unspentOutputs {
+		utxoID := utxoInput.ID()
+		if _, has := b.inputToAddr[utxoID]; has {
+			continue
+		}
+		deposit, err := output.Deposit()
+		if err != nil {
+			return 0, fmt.Errorf("unable to get deposit of output %s: %w", utxoID.ToHex(), err)
+		}
+		candidates = append(candidates, candidate{
+			input:   &ToBeSignedUTXOInput{Address: addr, Input: utxoInput, Output: output},
+			id:      utxoID,
+			deposit: deposit,
+		})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].deposit != candidates[j].deposit {
+			if strategy == TransactionBuilderInputSelectionSmallestFirst {
+				return candidates[i].deposit < candidates[j].deposit
+			}
+			return candidates[i].deposit > candidates[j].deposit
+		}
+		return bytes.Compare(candidates[i].id[:], candidates[j].id[:]) < 0
+	})
+
+	var sum uint64
+	var selected []*ToBeSignedUTXOInput
+	for _, c := range candidates {
+		if sum >= targetAmount {
+			if remainder := sum - targetAmount; remainder == 0 || remainder >= OutputSigLockedDustAllowanceOutputMinDeposit {
+				break
+			}
+		}
+		selected = append(selected, c.input)
+		sum += c.deposit
+	}
+
+	if sum < targetAmount {
+		return 0, fmt.Errorf("%w: unspent outputs of address %s only deposit %d but %d are needed", ErrTransactionBuilderInsufficientInputs, addr, sum, targetAmount)
+	}
+
+	b.AddInputs(selected...)
+	return sum, b.occurredBuildErr
 }
 
-// TransactionFunc is a function which receives a Transaction as its parameter.
-type TransactionFunc func(tx *Transaction)
+// TransactionBuilderInputMetadata is the metadata of an unspent output queried from a node.
+type TransactionBuilderInputMetadata struct {
+	// The ID of the message which created the output.
+	MessageID MessageID
+	// The index of the milestone which referenced the message creating the output, zero if not yet referenced.
+	ReferencedByMilestoneIndex uint32
+	// The ledger index of the node at the time the output was queried.
+	LedgerIndex uint64
+	// Whether the output got spent in the meantime.
+	Spent bool
+}
 
-// BuildAndSwapToMessageBuilder builds the transaction and then swaps to a MessageBuilder with
-// the transaction set as its payload. txFunc can be nil.
-func (b *TransactionBuilder) BuildAndSwapToMessageBuilder(signer AddressSigner, txFunc TransactionFunc) *MessageBuilder {
-	msgBuilder := NewMessageBuilder()
-	tx, err := b.Build(signer)
+// TransactionBuilderInputMetadataFilter works like TransactionBuilderInputFilter but additionally receives
+// the metadata of the output.
+type TransactionBuilderInputMetadataFilter func(utxoInput *UTXOInput, input Output, metadata *TransactionBuilderInputMetadata) bool
+
+// AddInputsViaNodeQueryWithMetadata works like AddInputsViaNodeQuery but additionally passes the metadata of every
+// unspent output to the filter, i.e. to only use outputs which were confirmed a certain amount of milestones ago.
+// Note that the metadata of the message creating an output is queried per output. filter can be nil.
+func (b *TransactionBuilder) AddInputsViaNodeQueryWithMetadata(ctx context.Context, addr Address, nodeHTTPAPIClient *NodeHTTPAPIClient, filter TransactionBuilderInputMetadataFilter) *TransactionBuilder {
+	ctx, cancel := b.nodeQueryContext(ctx)
+	defer cancel()
+
+	res, err := unspentOutputIDsByAddress(ctx, addr, nodeHTTPAPIClient)
 	if err != nil {
-		msgBuilder.err = err
-		return msgBuilder
+		b.occurredBuildErr = err
+		return b
 	}
-	if txFunc != nil {
-		txFunc(tx)
+
+	inputs := make([]*ToBeSignedUTXOInput, 0, len(res.OutputIDs))
+	for _, outputIDHex := range res.OutputIDs {
+		utxoInput, err := outputIDHex.AsUTXOInput()
+		if err != nil {
+			b.occurredBuildErr = err
+			return b
+		}
+
+		outputRes, err := nodeHTTPAPIClient.OutputByID(ctx, utxoInput.ID())
+		if err != nil {
+			b.occurredBuildErr = fmt.Errorf("unable to add inputs via node query: %w", err)
+			return b
+		}
+
+		output, err := outputRes.Output()
+		if err != nil {
+			b.occurredBuildErr = err
+			return b
+		}
+
+		metadata, err := inputMetadata(ctx, nodeHTTPAPIClient, outputRes)
+		if err != nil {
+			b.occurredBuildErr = fmt.Errorf("unable to add inputs via node query: %w", err)
+			return b
+		}
+
+		if filter != nil && !filter(utxoInput, output, metadata) {
+			continue
+		}
+
+		inputs = append(inputs, &ToBeSignedUTXOInput{Address: addr, Input: utxoInput, Output: output})
 	}
-	return msgBuilder.Payload(tx)
+
+	return b.addQueriedInputs(inputs)
 }
 
-// Build sings the inputs with the given signer and returns the built payload.
-func (b *TransactionBuilder) Build(signer AddressSigner) (*Transaction, error) {
+// queries the metadata of the message which created the output of the given response.
+func inputMetadata(ctx context.Context, nodeHTTPAPIClient *NodeHTTPAPIClient, outputRes *NodeOutputResponse) (*TransactionBuilderInputMetadata, error) {
+	metadata := &TransactionBuilderInputMetadata{LedgerIndex: outputRes.LedgerIndex, Spent: outputRes.Spent}
 
-	if b.occurredBuildErr != nil {
-		return nil, b.occurredBuildErr
+	msgIDBytes, err := hex.DecodeString(outputRes.MessageID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode message ID of output: %w", err)
+	}
+	if len(msgIDBytes) != MessageIDLength {
+		return nil, fmt.Errorf("invalid message ID length of output: expected %d bytes but got %d", MessageIDLength, len(msgIDBytes))
 	}
+	copy(metadata.MessageID[:], msgIDBytes)
 
-	// sort inputs and outputs by their serialized byte order
-	txEssenceData, err := b.essence.SigningMessage()
+	msgMetadata, err := nodeHTTPAPIClient.MessageMetadataByMessageID(ctx, metadata.MessageID)
 	if err != nil {
 		return nil, err
 	}
+	if msgMetadata.ReferencedByMilestoneIndex != nil {
+		metadata.ReferencedByMilestoneIndex = *msgMetadata.ReferencedByMilestoneIndex
+	}
 
-	sigBlockPos := map[string]int{}
-	unlockBlocks := serializer.Serializables{}
-	for i, input := range b.essence.Inputs {
-		addr := b.inputToAddr[input.(*UTXOInput).ID()]
-		addrStr := addr.(fmt.Stringer).String()
+	return metadata, nil
+}
 
-		// check whether a previous signature unlock block
-		// already signs inputs for the given address
-		pos, alreadySigned := sigBlockPos[addrStr]
-		if alreadySigned {
-			// create a reference unlock block instead
-			unlockBlocks = append(unlockBlocks, &ReferenceUnlockBlock{Reference: uint16(pos)})
+// queries the unspent outputs residing on the given address.
+func unspentOutputsByAddress(ctx context.Context, addr Address, nodeHTTPAPIClient *NodeHTTPAPIClient) (map[*UTXOInput]Output, error) {
+	res, err := unspentOutputIDsByAddress(ctx, addr, nodeHTTPAPIClient)
+	if err != nil {
+		return nil, err
+	}
+	_, outputs, err := nodeHTTPAPIClient.outputIDsToOutputsWithMetadata(ctx, res)
+	if err != nil {
+		return nil, err
+	}
+
+	unspentOutputs := make(map[*UTXOInput]Output, len(outputs))
+	for utxoInput, output := range outputs {
+		// the output might have been spent in between querying the output IDs and the output
+		if output.Spent {
 			continue
 		}
+		unspentOutputs[utxoInput] = output.Output
+	}
+	return unspentOutputs, nil
+}
 
-		// create a new signature for the given address
-		var signature serializer.Serializable
-		signature, err = signer.Sign(addr, txEssenceData)
+// queries the IDs of the unspent outputs residing on the given address by dispatching to the
+// NodeHTTPAPIClient endpoint corresponding to the type of the address.
+func unspentOutputIDsByAddress(ctx context.Context, addr Address, nodeHTTPAPIClient *NodeHTTPAPIClient) (*AddressOutputsResponse, error) {
+	switch x := addr.(type) {
+	case *Ed25519Address:
+		res, err := nodeHTTPAPIClient.OutputIDsByEd25519Address(ctx, x, false)
 		if err != nil {
 			return nil, err
 		}
-
-		unlockBlocks = append(unlockBlocks, &SignatureUnlockBlock{Signature: signature})
-		sigBlockPos[addrStr] = i
+		if res.MaxResultsReached() {
+			return nil, fmt.Errorf("%w: node returned its maximum of %d outputs for address %s, the result is likely incomplete", ErrTransactionBuilder, res.MaxResults, x)
+		}
+		return res, nil
+	default:
+		return nil, fmt.Errorf("%w: auto. inputs via node query only supports Ed25519Address but got %T", ErrTransactionBuilderUnsupportedAddress, x)
+	}
+}
+
+// InputSum returns the sum of the deposits of the outputs referenced by the added inputs.
+// An error is returned if the referenced output of any input is unknown to the builder,
+// which is the case if the input was added without its Output.
+func (b *TransactionBuilder) InputSum() (uint64, error) {
+	var sum uint64
+	for _, input := range b.essence.Inputs {
+		utxoID := input.(*UTXOInput).ID()
+		output, has := b.inputToOutput[utxoID]
+		if !has {
+			return 0, fmt.Errorf("%w: value of input %s is unknown as its referenced output was not provided", ErrTransactionBuilder, utxoID.ToHex())
+		}
+		deposit, err := output.Deposit()
+		if err != nil {
+			return 0, fmt.Errorf("unable to get deposit of output referenced by input %s: %w", utxoID.ToHex(), err)
+		}
+		sum += deposit
+	}
+	return sum, nil
+}
+
+// AddOutput adds the given output to the builder.
+func (b *TransactionBuilder) AddOutput(output Output) *TransactionBuilder {
+	b.essence.Outputs = append(b.essence.Outputs, output)
+	return b
+}
+
+// AddOutputWithUnit adds a SigLockedSingleOutput depositing the given amount onto the given address.
+// The amount is a decimal number optionally followed by a unit symbol such as "2 Mi" or "1.5 Gi", see units.ParseAmount.
+// An error is set if the amount can not be parsed, is zero or exceeds the total supply of tokens.
+func (b *TransactionBuilder) AddOutputWithUnit(addr Address, amount string) *TransactionBuilder {
+	iotas, err := units.ParseAmount(amount)
+	switch {
+	case err != nil:
+		b.occurredBuildErr = fmt.Errorf("%w: unable to parse amount %q: %s", ErrTransactionBuilder, amount, err)
+		return b
+	case iotas == 0:
+		b.occurredBuildErr = fmt.Errorf("%w: amount %q for address %s is zero", ErrTransactionBuilder, amount, addr)
+		return b
+	case iotas > TokenSupply:
+		b.occurredBuildErr = fmt.Errorf("%w: amount %q for address %s deposits more than the total supply of %d", ErrTransactionBuilderExceedsTokenSupply, amount, addr, TokenSupply)
+		return b
+	}
+	return b.AddOutput(&SigLockedSingleOutput{Address: addr, Amount: iotas})
+}
+
+// AddTaggedOutput adds the given output to the builder and associates the given opaque tag with it.
+// The tag is not part of the transaction but is returned by BuildWithOutputTags mapped to the ID of the output,
+// in order to correlate application-level data with the outputs produced by the transaction.
+// Re-adding the same output instance overrides its previous tag.
+func (b *TransactionBuilder) AddTaggedOutput(output Output, tag interface{}) *TransactionBuilder {
+	b.outputTags[output] = tag
+	return b.AddOutput(output)
+}
+
+// Payment defines an amount of tokens to deposit onto an address.
+type Payment struct {
+	// The address to deposit onto.
+	Address Address
+	// The amount to deposit.
+	Amount uint64
+}
+
+// AddPaymentOutputs adds a SigLockedSingleOutput per given Payment to the builder. If aggregate is true, the amounts
+// of payments to the same address are summed up into a single output, placed at the first occurrence of the address.
+// An error is set if any payment has a zero amount.
+func (b *TransactionBuilder) AddPaymentOutputs(payments []Payment, aggregate bool) *TransactionBuilder {
+	outputs := make([]*SigLockedSingleOutput, 0, len(payments))
+	addrOutputs := make(map[string]*SigLockedSingleOutput)
+	for i, payment := range payments {
+		if payment.Amount == 0 {
+			b.occurredBuildErr = fmt.Errorf("%w: payment at index %d to address %s has a zero amount", ErrTransactionBuilder, i, payment.Address)
+			return b
+		}
+
+		addrKey, err := addressKey(payment.Address)
+		if err != nil {
+			b.occurredBuildErr = fmt.Errorf("%w: payment at index %d: %s", ErrTransactionBuilder, i, err)
+			return b
+		}
+
+		if aggregate {
+			if output, has := addrOutputs[addrKey]; has {
+				if output.Amount+payment.Amount < output.Amount {
+					b.occurredBuildErr = fmt.Errorf("%w: aggregated payments to address %s overflow", ErrTransactionBuilder, payment.Address)
+					return b
+				}
+				output.Amount += payment.Amount
+				continue
+			}
+		}
+
+		output := &SigLockedSingleOutput{Address: payment.Address, Amount: payment.Amount}
+		addrOutputs[addrKey] = output
+		outputs = append(outputs, output)
+	}
+
+	for _, output := range outputs {
+		b.AddOutput(output)
+	}
+	return b
+}
+
+// AddRemainderOutput instructs the builder to deposit the remainder of the input sum minus the output sum
+// onto the given address. The remainder is computed at the time of Build, which therefore requires that the
+// output referenced by each input is known to the builder (see InputSum). No remainder output is added if
+// the remainder is zero and Build fails if the outputs deposit more than the inputs.
+func (b *TransactionBuilder) AddRemainderOutput(remainderAddr Address) *TransactionBuilder {
+	b.remainderAddr = remainderAddr
+	return b
+}
+
+// WithRemainderMode sets the TransactionBuilderRemainderMode used by Build.
+func (b *TransactionBuilder) WithRemainderMode(remainderMode TransactionBuilderRemainderMode) *TransactionBuilder {
+	b.remainderMode = remainderMode
+	return b
+}
+
+// AddTreasuryInput adds the given TreasuryInput to the builder.
+// A TreasuryInput is unlocked via the receipt of the milestone which contains the resulting TreasuryTransaction
+// and therefore can not be mixed with UTXO inputs. Use BuildTreasuryTransaction to build the TreasuryTransaction.
+// This function overrides the previously added TreasuryInput.
+func (b *TransactionBuilder) AddTreasuryInput(input *TreasuryInput) *TransactionBuilder {
+	b.treasuryInput = input
+	return b
+}
+
+// AddTreasuryOutput adds the given TreasuryOutput to the builder.
+// This function overrides the previously added TreasuryOutput.
+func (b *TransactionBuilder) AddTreasuryOutput(output *TreasuryOutput) *TransactionBuilder {
+	b.treasuryOutput = output
+	return b
+}
+
+// AddIndexationPayload adds the given Indexation as the inner payload.
+// Adding a nil payload or a payload when one was already added results in an error on Build.
+func (b *TransactionBuilder) AddIndexationPayload(payload *Indexation) *TransactionBuilder {
+	if payload == nil {
+		b.occurredBuildErr = fmt.Errorf("%w: indexation payload is nil", ErrTransactionBuilderInvalidEssencePayload)
+		return b
+	}
+
+	if b.essence.Payload != nil {
+		b.occurredBuildErr = fmt.Errorf("%w: an indexation payload was already added", ErrTransactionBuilder)
+		return b
+	}
+
+	switch indexLen := len(payload.Index); {
+	case indexLen < IndexationIndexMinLength:
+		b.occurredBuildErr = fmt.Errorf("%w: index has a length of %d but the min is %d", ErrIndexationIndexUnderMinSize, indexLen, IndexationIndexMinLength)
+		return b
+	case indexLen > IndexationIndexMaxLength:
+		b.occurredBuildErr = fmt.Errorf("%w: index has a length of %d but the max is %d", ErrIndexationIndexExceedsMaxSize, indexLen, IndexationIndexMaxLength)
+		return b
+	}
+
+	payloadData, err := payload.Serialize(serializer.DeSeriModeNoValidation)
+	if err != nil {
+		b.occurredBuildErr = fmt.Errorf("unable to serialize indexation payload: %w", err)
+		return b
+	}
+	if len(payloadData) > MessageBinSerializedMaxSize {
+		b.occurredBuildErr = fmt.Errorf("%w: indexation payload has a size of %d bytes but a message can at most be %d bytes", ErrTransactionBuilder, len(payloadData), MessageBinSerializedMaxSize)
+		return b
+	}
+
+	b.essence.Payload = payload
+	return b
+}
+
+// AddIndexationData adds an Indexation with the given index and data as the inner payload, i.e. to tag the
+// transaction with a memo. The index and data are validated like by AddIndexationPayload.
+func (b *TransactionBuilder) AddIndexationData(index []byte, data []byte) *TransactionBuilder {
+	return b.AddIndexationPayload(&Indexation{Index: index, Data: data})
+}
+
+// TransactionFunc is a function which receives a Transaction as its parameter.
+type TransactionFunc func(tx *Transaction)
+
+// BuildAndSwapToMessageBuilder builds the transaction and then swaps to a MessageBuilder with
+// the transaction set as its payload. txFunc can be nil.
+func (b *TransactionBuilder) BuildAndSwapToMessageBuilder(signer AddressSigner, txFunc TransactionFunc) *MessageBuilder {
+	msgBuilder := NewMessageBuilder()
+	tx, err := b.Build(signer)
+	if err != nil {
+		msgBuilder.err = err
+		return msgBuilder
+	}
+	if txFunc != nil {
+		txFunc(tx)
+	}
+	return msgBuilder.Payload(tx)
+}
+
+// BuildAndSwapToMessageBuilderWithParents works like BuildAndSwapToMessageBuilder but additionally
+// sets the given parents on the returned MessageBuilder. txFunc can be nil.
+func (b *TransactionBuilder) BuildAndSwapToMessageBuilderWithParents(signer AddressSigner, txFunc TransactionFunc, parents MessageIDs) *MessageBuilder {
+	return b.BuildAndSwapToMessageBuilder(signer, txFunc).ParentsMessageIDs(parents)
+}
+
+// BuildEssence finalizes the TransactionEssence without signing it and returns it together with its signing message.
+// The same steps as within Build are performed (computing the remainder, sorting the inputs and outputs etc.),
+// which allows to sign the signing message externally, i.e. on an air-gapped device.
+func (b *TransactionBuilder) BuildEssence() (*TransactionEssence, []byte, error) {
+	if b.occurredBuildErr != nil {
+		return nil, nil, b.occurredBuildErr
+	}
+
+	if err := b.checkNoTreasuryMix(); err != nil {
+		return nil, nil, err
+	}
+
+	if b.treasuryInput != nil || b.treasuryOutput != nil {
+		return nil, nil, fmt.Errorf("%w: treasury inputs/outputs are not unlocked via signatures, use BuildTreasuryTransaction instead", ErrTransactionBuilder)
+	}
+
+	if b.essence.Payload != nil {
+		if _, isIndexation := b.essence.Payload.(*Indexation); !isIndexation {
+			return nil, nil, fmt.Errorf("%w: transaction essences only allow an embedded indexation payload but got %T", ErrTransactionBuilderInvalidEssencePayload, b.essence.Payload)
+		}
+	}
+
+	if len(b.essence.Inputs) == 0 {
+		return nil, nil, fmt.Errorf("%w, add inputs via AddInput(s) or AddInputsViaNodeQuery", ErrTransactionBuilderNoInputs)
+	}
+
+	if err := b.addRemainderOutput(); err != nil {
+		return nil, nil, err
+	}
+
+	if len(b.essence.Outputs) == 0 {
+		return nil, nil, fmt.Errorf("%w, add outputs via AddOutput or AddRemainderOutput", ErrTransactionBuilderNoOutputs)
+	}
+
+	if len(b.essence.Inputs) > MaxInputsCount {
+		return nil, nil, fmt.Errorf("%w: %d inputs exceed the max inputs count of %d by %d", ErrTransactionBuilderTooManyInputs, len(b.essence.Inputs), MaxInputsCount, len(b.essence.Inputs)-MaxInputsCount)
+	}
+
+	if len(b.essence.Outputs) > MaxOutputsCount {
+		return nil, nil, fmt.Errorf("%w: %d outputs exceed the max outputs count of %d by %d", ErrTransactionBuilderTooManyOutputs, len(b.essence.Outputs), MaxOutputsCount, len(b.essence.Outputs)-MaxOutputsCount)
+	}
+
+	if _, err := b.outputSum(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := b.checkStorageDeposits(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := b.checkMinOutputAmounts(); err != nil {
+		return nil, nil, err
+	}
+
+	txEssenceData, err := b.signingMessage()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return b.essence, txEssenceData, nil
+}
+
+// checks whether every output covers its minimum storage deposit under the set RentStructure
+// and otherwise returns an error listing all underfunded outputs.
+func (b *TransactionBuilder) checkStorageDeposits() error {
+	if b.rentStruct == nil {
+		return nil
+	}
+
+	var underfunded []string
+	for i, output := range b.essence.Outputs {
+		out := output.(Output)
+		deposit, err := out.Deposit()
+		if err != nil {
+			return err
+		}
+		if minDeposit := b.rentStruct.MinStorageDeposit(out); deposit < minDeposit {
+			underfunded = append(underfunded, fmt.Sprintf("output %d deposits %d but requires %d", i, deposit, minDeposit))
+		}
+	}
+
+	if len(underfunded) > 0 {
+		return fmt.Errorf("%w: %s", ErrTransactionBuilderStorageDepositNotCovered, strings.Join(underfunded, ", "))
+	}
+	return nil
+}
+
+// checks that every SigLockedSingleOutput deposits at least the threshold set via WithMinOutputAmount
+// or is covered by the dust allowance deposited onto its address within the transaction.
+func (b *TransactionBuilder) checkMinOutputAmounts() error {
+	if b.minOutputAmount == 0 {
+		return nil
+	}
+
+	dustAllowances := map[string]uint64{}
+	for i, output := range b.essence.Outputs {
+		if allowanceOutput, ok := output.(*SigLockedDustAllowanceOutput); ok {
+			if addr, ok := allowanceOutput.Address.(Address); ok {
+				addrKey, err := addressKey(addr)
+				if err != nil {
+					return fmt.Errorf("%w: output at index %d: %s", ErrTransactionBuilder, i, err)
+				}
+				dustAllowances[addrKey] += allowanceOutput.Amount
+			}
+		}
+	}
+
+	dustOutputs := map[string]uint64{}
+	var offending []string
+	for i, output := range b.essence.Outputs {
+		singleOutput, ok := output.(*SigLockedSingleOutput)
+		if !ok || singleOutput.Amount >= b.minOutputAmount {
+			continue
+		}
+
+		addr, ok := singleOutput.Address.(Address)
+		if !ok {
+			offending = append(offending, fmt.Sprintf("output %d deposits %d", i, singleOutput.Amount))
+			continue
+		}
+
+		addrKey, err := addressKey(addr)
+		if err != nil {
+			return fmt.Errorf("%w: output at index %d: %s", ErrTransactionBuilder, i, err)
+		}
+		dustOutputs[addrKey]++
+		if dustOutputs[addrKey] > dustAllowances[addrKey]/uint64(DustAllowanceDivisor) {
+			offending = append(offending, fmt.Sprintf("output %d deposits %d to %s", i, singleOutput.Amount, addr))
+		}
+	}
+
+	if len(offending) > 0 {
+		return fmt.Errorf("%w: outputs below the min output amount of %d not covered by a dust allowance: %s", ErrTransactionBuilderDustViolation, b.minOutputAmount, strings.Join(offending, ", "))
+	}
+	return nil
+}
+
+// Build sings the inputs with the given signer and returns the built payload.
+// Inputs belonging to an address for which an AddressSigner was registered via WithSignerForAddress
+// are signed by that AddressSigner instead. signer can be nil if every address has a registered AddressSigner.
+// Sign is called exactly once per distinct input address, as all signatures are made over the same signing message
+// and further inputs of the same address are unlocked via ReferenceUnlockBlocks.
+func (b *TransactionBuilder) Build(signer AddressSigner) (*Transaction, error) {
+
+	_, txEssenceData, err := b.BuildEssence()
+	if err != nil {
+		return nil, err
+	}
+
+	sigBlockPos := map[string]int{}
+	unlockBlocks := serializer.Serializables{}
+	for i, input := range b.essence.Inputs {
+		addr := b.inputToAddr[input.(*UTXOInput).ID()]
+		addrStr := addr.String()
+		addrKey, err := addressKey(addr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: input at index %d: %s", ErrTransactionBuilder, i, err)
+		}
+
+		// check whether a previous signature unlock block
+		// already signs inputs for the given address
+		pos, alreadySigned := sigBlockPos[addrKey]
+		if alreadySigned {
+			// create a reference unlock block instead
+			unlockBlocks = append(unlockBlocks, &ReferenceUnlockBlock{Reference: uint16(pos)})
+			continue
+		}
+
+		addrSigner, has := b.addrSigners[addrKey]
+		if !has {
+			addrSigner = signer
+		}
+		if addrSigner == nil {
+			return nil, fmt.Errorf("%w: for address %s of input at index %d", ErrTransactionBuilderNoSigner, addrStr, i)
+		}
+
+		// create a new signature for the given address
+		var signature serializer.Serializable
+		signature, err = addrSigner.Sign(addr, txEssenceData)
+		if err != nil {
+			return nil, err
+		}
+
+		unlockBlocks = append(unlockBlocks, &SignatureUnlockBlock{Signature: signature})
+		sigBlockPos[addrKey] = i
+	}
+
+	if err := b.checkUnlockBlocksAddrs(unlockBlocks); err != nil {
+		return nil, err
+	}
+
+	if err := b.checkSignatures(unlockBlocks, txEssenceData); err != nil {
+		return nil, err
 	}
 
 	sigTxPayload := &Transaction{Essence: b.essence, UnlockBlocks: unlockBlocks}
 
 	return sigTxPayload, nil
 }
+
+// BuildWithOutputTags builds the Transaction like Build and additionally returns the tags of the outputs
+// added via AddTaggedOutput mapped to the IDs of these outputs within the built Transaction.
+// As the outputs might get sorted on Build, the mapping reflects their final position.
+func (b *TransactionBuilder) BuildWithOutputTags(signer AddressSigner) (*Transaction, map[UTXOInputID]interface{}, error) {
+	tx, err := b.Build(signer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	outputIDs, err := tx.OutputIDs()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tags := make(map[UTXOInputID]interface{}, len(b.outputTags))
+	for i, output := range b.essence.Outputs {
+		if tag, has := b.outputTags[output.(Output)]; has {
+			tags[outputIDs[i]] = tag
+		}
+	}
+
+	return tx, tags, nil
+}
+
+// EstimatedSize returns the size of the serialized Transaction which Build would produce given the current state of the builder.
+// Signatures are substituted by empty signatures of the size corresponding to the address type of the inputs,
+// thereby the estimate matches the size of the built Transaction. The state of the builder is not modified.
+func (b *TransactionBuilder) EstimatedSize() (int, error) {
+	clone := b.Clone()
+	essence, _, err := clone.BuildEssence()
+	if err != nil {
+		return 0, err
+	}
+
+	sigBlockPos := map[string]int{}
+	unlockBlocks := serializer.Serializables{}
+	for i, input := range essence.Inputs {
+		addr := clone.inputToAddr[input.(*UTXOInput).ID()]
+		addrKey, err := addressKey(addr)
+		if err != nil {
+			return 0, fmt.Errorf("%w: input at index %d: %s", ErrTransactionBuilder, i, err)
+		}
+
+		if pos, alreadySigned := sigBlockPos[addrKey]; alreadySigned {
+			unlockBlocks = append(unlockBlocks, &ReferenceUnlockBlock{Reference: uint16(pos)})
+			continue
+		}
+
+		var signature serializer.Serializable
+		switch addr.(type) {
+		case *Ed25519Address:
+			signature = &Ed25519Signature{}
+		default:
+			return 0, fmt.Errorf("%w: unable to estimate signature size for address type %T of input at index %d", ErrTransactionBuilderUnsupportedAddress, addr, i)
+		}
+
+		unlockBlocks = append(unlockBlocks, &SignatureUnlockBlock{Signature: signature})
+		sigBlockPos[addrKey] = i
+	}
+
+	txData, err := (&Transaction{Essence: essence, UnlockBlocks: unlockBlocks}).Serialize(serializer.DeSeriModeNoValidation)
+	if err != nil {
+		return 0, fmt.Errorf("unable to estimate transaction size: %w", err)
+	}
+
+	return len(txData), nil
+}
+
+// TransactionPreview is a human readable summary of the transaction a TransactionBuilder would build,
+// i.e. to let a user confirm a transaction before it is signed.
+type TransactionPreview struct {
+	// The sum of the deposits of the outputs referenced by the inputs.
+	InputSum uint64
+	// The sum of the deposits of the outputs, including the change.
+	OutputSum uint64
+	// The amounts deposited per recipient keyed by the bech32 address of the recipient, excluding the change.
+	Recipients map[string]uint64
+	// The amount deposited back as change onto the address given via AddRemainderOutput, zero if there is no change.
+	Change uint64
+	// The bech32 address receiving the change, empty if there is no change.
+	ChangeAddress string
+}
+
+// Preview returns a TransactionPreview of the transaction Build would produce given the current state of the builder,
+// rendering addresses as bech32 strings with the given human readable part. The change is computed like by Build.
+// An error is returned if the referenced output of any input is unknown to the builder (see InputSum) or if the
+// outputs deposit more than the inputs. Nothing is signed and the state of the builder is not modified.
+func (b *TransactionBuilder) Preview(hrp NetworkPrefix) (*TransactionPreview, error) {
+	if b.occurredBuildErr != nil {
+		return nil, b.occurredBuildErr
+	}
+
+	clone := b.Clone()
+	inputSum, err := clone.InputSum()
+	if err != nil {
+		return nil, fmt.Errorf("unable to preview transaction: %w", err)
+	}
+
+	if err := clone.addRemainderOutput(); err != nil {
+		return nil, fmt.Errorf("unable to preview transaction: %w", err)
+	}
+
+	outputSum, err := clone.outputSum()
+	if err != nil {
+		return nil, fmt.Errorf("unable to preview transaction: %w", err)
+	}
+	if outputSum > inputSum {
+		return nil, fmt.Errorf("%w: the outputs deposit more than the inputs (inputs sum %d, outputs sum %d)", ErrTransactionBuilderInsufficientInputs, inputSum, outputSum)
+	}
+
+	preview := &TransactionPreview{InputSum: inputSum, OutputSum: outputSum, Recipients: map[string]uint64{}}
+	for _, output := range clone.essence.Outputs {
+		out := output.(Output)
+		deposit, _ := out.Deposit()
+		if out == clone.remainderOutput {
+			preview.Change = deposit
+			preview.ChangeAddress = clone.remainderAddr.Bech32(hrp)
+			continue
+		}
+
+		target, err := out.Target()
+		if err != nil {
+			return nil, fmt.Errorf("unable to preview transaction: %w", err)
+		}
+		addr, ok := target.(Address)
+		if !ok {
+			return nil, fmt.Errorf("%w: unable to preview output of type %T without an address target", ErrTransactionBuilder, out)
+		}
+		preview.Recipients[addr.Bech32(hrp)] += deposit
+	}
+
+	return preview, nil
+}
+
+// BuildWithUnlockBlocks finalizes the TransactionEssence (see BuildEssence) and assembles the Transaction using the given
+// externally produced unlock blocks instead of signing the inputs. The unlock blocks must be ordered in accordance with the
+// sorted inputs of the essence: the first input of an address must be unlocked by a SignatureUnlockBlock and every further input
+// of the same address by a ReferenceUnlockBlock referencing it. The assembled Transaction is serialized with validation.
+func (b *TransactionBuilder) BuildWithUnlockBlocks(unlockBlocks serializer.Serializables) (*Transaction, error) {
+	essence, txEssenceData, err := b.BuildEssence()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.checkUnlockBlocksOrder(unlockBlocks); err != nil {
+		return nil, err
+	}
+
+	if err := b.checkUnlockBlocksAddrs(unlockBlocks); err != nil {
+		return nil, err
+	}
+
+	if err := b.checkSignatures(unlockBlocks, txEssenceData); err != nil {
+		return nil, err
+	}
+
+	tx := &Transaction{Essence: essence, UnlockBlocks: unlockBlocks}
+	if _, err := tx.Serialize(serializer.DeSeriModePerformValidation); err != nil {
+		return nil, fmt.Errorf("unable to build transaction with the given unlock blocks: %w", err)
+	}
+
+	return tx, nil
+}
+
+// checks that the given unlock blocks match the inputs of the essence in count and order.
+func (b *TransactionBuilder) checkUnlockBlocksOrder(unlockBlocks serializer.Serializables) error {
+	if len(unlockBlocks) != len(b.essence.Inputs) {
+		return fmt.Errorf("%w: %d unlock blocks given for %d inputs", ErrTransactionBuilderUnlockBlocksMismatch, len(unlockBlocks), len(b.essence.Inputs))
+	}
+
+	sigBlockPos := map[string]int{}
+	for i, input := range b.essence.Inputs {
+		addr := b.inputToAddr[input.(*UTXOInput).ID()]
+		addrStr := addr.String()
+		addrKey, err := addressKey(addr)
+		if err != nil {
+			return fmt.Errorf("%w: input at index %d: %s", ErrTransactionBuilder, i, err)
+		}
+		pos, alreadySigned := sigBlockPos[addrKey]
+
+		switch ub := unlockBlocks[i].(type) {
+		case *SignatureUnlockBlock:
+			if alreadySigned {
+				return fmt.Errorf("%w: input at index %d must be unlocked by a reference unlock block referencing %d as its address %s is already signed", ErrTransactionBuilderUnlockBlocksMismatch, i, pos, addrStr)
+			}
+			sigBlockPos[addrKey] = i
+		case *ReferenceUnlockBlock:
+			if !alreadySigned {
+				return fmt.Errorf("%w: input at index %d must be unlocked by a signature unlock block as its address %s is not yet signed", ErrTransactionBuilderUnlockBlocksMismatch, i, addrStr)
+			}
+			if int(ub.Reference) != pos {
+				return fmt.Errorf("%w: reference unlock block at index %d references %d instead of %d", ErrTransactionBuilderUnlockBlocksMismatch, i, ub.Reference, pos)
+			}
+		default:
+			return fmt.Errorf("%w: unlock block at index %d is of unknown type %T", ErrUnknownUnlockBlockType, i, ub)
+		}
+	}
+
+	return nil
+}
+
+// checks that every unlock block resolves to a SignatureUnlockBlock (directly or via a ReferenceUnlockBlock)
+// of which the signature corresponds to the address of the input at the same index.
+func (b *TransactionBuilder) checkUnlockBlocksAddrs(unlockBlocks serializer.Serializables) error {
+	for i, input := range b.essence.Inputs {
+		addr := b.inputToAddr[input.(*UTXOInput).ID()]
+
+		sigBlockIndex := i
+		if refBlock, isRefBlock := unlockBlocks[i].(*ReferenceUnlockBlock); isRefBlock {
+			sigBlockIndex = int(refBlock.Reference)
+			if sigBlockIndex >= len(unlockBlocks) {
+				return fmt.Errorf("%w: reference unlock block at index %d references non existent unlock block %d", ErrTransactionBuilderUnlockBlocksMismatch, i, sigBlockIndex)
+			}
+		}
+
+		sigBlock, isSigBlock := unlockBlocks[sigBlockIndex].(*SignatureUnlockBlock)
+		if !isSigBlock {
+			return fmt.Errorf("%w: unlock block at index %d does not resolve to a signature unlock block (resolved to %T at index %d)", ErrTransactionBuilderUnlockBlocksMismatch, i, unlockBlocks[sigBlockIndex], sigBlockIndex)
+		}
+
+		switch sig := sigBlock.Signature.(type) {
+		case *Ed25519Signature:
+			edAddr, isEdAddr := addr.(*Ed25519Address)
+			if !isEdAddr {
+				return fmt.Errorf("%w: input at index %d has address of type %T but its signature unlock block at index %d holds an Ed25519 signature", ErrTransactionBuilderUnlockBlocksMismatch, i, addr, sigBlockIndex)
+			}
+			if sigAddr := AddressFromEd25519PubKey(sig.PublicKey[:]); sigAddr != *edAddr {
+				return fmt.Errorf("%w: signature unlock block at index %d unlocks address %s instead of %s of input at index %d", ErrTransactionBuilderUnlockBlocksMismatch, sigBlockIndex, sigAddr.String(), edAddr, i)
+			}
+		default:
+			return fmt.Errorf("%w: signature unlock block at index %d holds unknown signature type %T", ErrTransactionBuilderUnlockBlocksMismatch, sigBlockIndex, sig)
+		}
+	}
+	return nil
+}
+
+// verifies the signatures of the given unlock blocks over the given signing message if WithPostSignVerification is set.
+// The unlock blocks must have passed checkUnlockBlocksAddrs beforehand.
+func (b *TransactionBuilder) checkSignatures(unlockBlocks serializer.Serializables, txEssenceData []byte) error {
+	if !b.verifySigs {
+		return nil
+	}
+
+	for i, input := range b.essence.Inputs {
+		sigBlock, isSigBlock := unlockBlocks[i].(*SignatureUnlockBlock)
+		if !isSigBlock {
+			continue
+		}
+		addr := b.inputToAddr[input.(*UTXOInput).ID()]
+		if err := VerifySignature(addr, sigBlock.Signature, txEssenceData); err != nil {
+			return fmt.Errorf("%w: signature unlock block at index %d does not verify: %s", ErrTransactionBuilderInvalidSignature, i, err)
+		}
+	}
+	return nil
+}
+
+// BuildTreasuryTransaction builds a TreasuryTransaction out of the added TreasuryInput and TreasuryOutput.
+// No signatures are produced as the TreasuryInput is unlocked via the receipt in which the TreasuryTransaction is embedded.
+func (b *TransactionBuilder) BuildTreasuryTransaction() (*TreasuryTransaction, error) {
+	if b.occurredBuildErr != nil {
+		return nil, b.occurredBuildErr
+	}
+
+	if err := b.checkNoTreasuryMix(); err != nil {
+		return nil, err
+	}
+
+	if b.treasuryInput == nil || b.treasuryOutput == nil {
+		return nil, fmt.Errorf("%w: a treasury transaction requires a treasury input and a treasury output", ErrTransactionBuilder)
+	}
+
+	treasuryTx := &TreasuryTransaction{Input: b.treasuryInput, Output: b.treasuryOutput}
+	if _, err := treasuryTx.Serialize(serializer.DeSeriModePerformValidation); err != nil {
+		return nil, fmt.Errorf("unable to build treasury transaction: %w", err)
+	}
+
+	return treasuryTx, nil
+}
+
+// computes the remainder and adds the corresponding output if AddRemainderOutput was used.
+// a remainder output added by a previous call is replaced.
+func (b *TransactionBuilder) addRemainderOutput() error {
+	if b.remainderAddr == nil {
+		return nil
+	}
+
+	if b.remainderOutput != nil {
+		for i, output := range b.essence.Outputs {
+			if output == b.remainderOutput {
+				b.essence.Outputs = append(b.essence.Outputs[:i], b.essence.Outputs[i+1:]...)
+				break
+			}
+		}
+		b.remainderOutput = nil
+	}
+
+	inputSum, err := b.InputSum()
+	if err != nil {
+		return fmt.Errorf("unable to compute remainder: %w", err)
+	}
+
+	outputSum, err := b.outputSum()
+	if err != nil {
+		return fmt.Errorf("unable to compute remainder: %w", err)
+	}
+
+	switch {
+	case outputSum > inputSum:
+		return fmt.Errorf("%w: unable to compute remainder as the outputs deposit more than the inputs (inputs sum %d, outputs sum %d)", ErrTransactionBuilderInsufficientInputs, inputSum, outputSum)
+	case outputSum == inputSum:
+		return nil
+	}
+
+	remainder := inputSum - outputSum
+	if b.remainderMode == TransactionBuilderRemainderModeErrorIfDust && remainder < OutputSigLockedDustAllowanceOutputMinDeposit {
+		return fmt.Errorf("%w: remainder of %d is below the dust threshold of %d, add more inputs or lower the outputs' deposits so that no or a remainder of at least the threshold is left", ErrTransactionBuilderDustViolation, remainder, OutputSigLockedDustAllowanceOutputMinDeposit)
+	}
+
+	b.remainderOutput = &SigLockedSingleOutput{Address: b.remainderAddr, Amount: remainder}
+	b.essence.Outputs = append(b.essence.Outputs, b.remainderOutput)
+	return nil
+}
+
+// returns the sum of the deposits of the added outputs or an error if any output deposits zero tokens
+// or the sum exceeds the total supply of tokens.
+func (b *TransactionBuilder) outputSum() (uint64, error) {
+	var sum uint64
+	for i, output := range b.essence.Outputs {
+		deposit, err := output.(Output).Deposit()
+		if err != nil {
+			return 0, fmt.Errorf("unable to get deposit of output at index %d: %w", i, err)
+		}
+		if deposit == 0 {
+			return 0, fmt.Errorf("%w: output at index %d deposits zero tokens", ErrTransactionBuilderZeroDepositOutput, i)
+		}
+		// checked as a subtraction, so that sum+deposit can not overflow
+		if deposit > TokenSupply-sum {
+			return 0, fmt.Errorf("%w: the accumulated deposits of the outputs up to index %d exceed the total supply of %d", ErrTransactionBuilderExceedsTokenSupply, i, TokenSupply)
+		}
+		sum += deposit
+	}
+	return sum, nil
+}
+
+// returns the signing message of the essence in accordance with the builder's TransactionBuilderSortMode.
+func (b *TransactionBuilder) signingMessage() ([]byte, error) {
+	if b.sortMode == TransactionBuilderSortModeLexical {
+		// sort inputs and outputs by their serialized byte order
+		return b.essence.SigningMessage()
+	}
+
+	essenceBytes, err := b.essence.Serialize(serializer.DeSeriModeNoValidation)
+	if err != nil {
+		return nil, err
+	}
+	essenceBytesHash := blake2b.Sum256(essenceBytes)
+	return essenceBytesHash[:], nil
+}
+
+// checks that treasury inputs/outputs are not mixed with UTXO inputs or signature locked outputs.
+func (b *TransactionBuilder) checkNoTreasuryMix() error {
+	if b.treasuryInput == nil && b.treasuryOutput == nil {
+		return nil
+	}
+	if len(b.essence.Inputs) > 0 || len(b.essence.Outputs) > 0 {
+		return fmt.Errorf("%w: treasury inputs/outputs can not be mixed with UTXO inputs or other outputs (%d inputs, %d outputs)", ErrTransactionBuilder, len(b.essence.Inputs), len(b.essence.Outputs))
+	}
+	return nil
+}
+
+// BuildWithDustValidation works like Build but additionally verifies that the built transaction fulfils the dust protection
+// rules (see NewDustSemanticValidation). The current dust allowance state of the affected addresses and the UTXOs referenced
+// by the inputs are queried via the given NodeHTTPAPIClient.
+func (b *TransactionBuilder) BuildWithDustValidation(ctx context.Context, nodeHTTPAPIClient *NodeHTTPAPIClient, signer AddressSigner) (*Transaction, error) {
+	tx, err := b.Build(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	utxos, err := nodeHTTPAPIClient.inputUTXOs(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query UTXOs for dust validation: %w", err)
+	}
+
+	dustValidation := NewDustSemanticValidation(DustAllowanceDivisor, MaxDustOutputsOnAddress, nodeDustAllowanceFunc(ctx, nodeHTTPAPIClient))
+	if err := dustValidation(tx, utxos); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrTransactionBuilderDustViolation, err)
+	}
+
+	return tx, nil
+}
+
+// returns a DustAllowanceFunc which computes the dust allowance state of an address
+// by querying its unspent outputs via the given NodeHTTPAPIClient.
+func nodeDustAllowanceFunc(ctx context.Context, nodeHTTPAPIClient *NodeHTTPAPIClient) DustAllowanceFunc {
+	return func(addr Address) (uint64, int64, error) {
+		unspentOutputs, err := unspentOutputsByAddress(ctx, addr, nodeHTTPAPIClient)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		var dustAllowanceSum uint64
+		var amountDustOutputs int64
+		for _, output := range unspentOutputs {
+			deposit, err := output.Deposit()
+			if err != nil {
+				return 0, 0, err
+			}
+			switch output.(type) {
+			case *SigLockedDustAllowanceOutput:
+				dustAllowanceSum += deposit
+			case *SigLockedSingleOutput:
+				if deposit < OutputSigLockedDustAllowanceOutputMinDeposit {
+					amountDustOutputs++
+				}
+			}
+		}
+
+		return dustAllowanceSum, amountDustOutputs, nil
+	}
+}
+
+// BuildConsolidation queries the unspent outputs residing on the given address and consolidates them into
+// a single SigLockedSingleOutput back onto the same address. As a transaction can at most hold MaxInputsCount
+// inputs, multiple transactions are built if the address holds more unspent outputs. Every transaction is wrapped
+// into a message referencing tips of the given node and carrying its network ID, the proof-of-work however is not
+// performed on the returned messages. Nothing is consolidated if the address holds less than two unspent outputs.
+func BuildConsolidation(ctx context.Context, nodeHTTPAPIClient *NodeHTTPAPIClient, addr Address, signer AddressSigner) ([]*Message, error) {
+	unspentOutputs, err := unspentOutputsByAddress(ctx, addr, nodeHTTPAPIClient)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make([]*ToBeSignedUTXOInput, 0, len(unspentOutputs))
+	for utxoInput, output := range unspentOutputs {
+		inputs = append(inputs, &ToBeSignedUTXOInput{Address: addr, Input: utxoInput, Output: output})
+	}
+
+	// deterministic chunking across calls
+	sort.Slice(inputs, func(i, j int) bool {
+		iID, jID := inputs[i].Input.ID(), inputs[j].Input.ID()
+		return bytes.Compare(iID[:], jID[:]) < 0
+	})
+
+	if len(inputs) < 2 {
+		return nil, nil
+	}
+
+	info, err := nodeHTTPAPIClient.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var msgs []*Message
+	for len(inputs) > 0 {
+		chunkSize := len(inputs)
+		if chunkSize > MaxInputsCount {
+			chunkSize = MaxInputsCount
+		}
+		// consolidating a lone leftover input would just move it onto a new output
+		if chunkSize == 1 {
+			break
+		}
+
+		msg, err := NewTransactionBuilder().
+			AddInputs(inputs[:chunkSize]...).
+			AddRemainderOutput(addr).
+			BuildAndSwapToMessageBuilder(signer, nil).
+			NetworkIDFromString(info.NetworkID).
+			Tips(ctx, nodeHTTPAPIClient).
+			Build()
+		if err != nil {
+			return nil, fmt.Errorf("unable to build consolidation message %d: %w", len(msgs), err)
+		}
+
+		msgs = append(msgs, msg)
+		inputs = inputs[chunkSize:]
+	}
+
+	return msgs, nil
+}
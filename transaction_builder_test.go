@@ -1,13 +1,21 @@
 package iotago_test
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"github.com/iotaledger/hive.go/serializer"
 	"github.com/iotaledger/iota.go/v2/tpkg"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/iotaledger/iota.go/v2"
 	"github.com/iotaledger/iota.go/v2/ed25519"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
 )
 
 func TestTransactionBuilder(t *testing.T) {
@@ -52,13 +60,194 @@ func TestTransactionBuilder(t *testing.T) {
 				builder:    builder,
 			}
 		}(),
+		func() test {
+			outputAddr1, _ := tpkg.RandEd25519Address()
+			inputUTXO1, _ := tpkg.RandUTXOInput()
+			inputUTXO2, _ := tpkg.RandUTXOInput()
+
+			builder := iotago.NewTransactionBuilder().
+				AddInputs(
+					&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1},
+					&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO2},
+				).
+				AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 50})
+
+			return test{
+				name:       "ok - batch added inputs",
+				addrSigner: iotago.NewInMemoryAddressSigner(addrKeys),
+				builder:    builder,
+			}
+		}(),
+		func() test {
+			outputAddr1, _ := tpkg.RandEd25519Address()
+			inputUTXO1, _ := tpkg.RandUTXOInput()
+			duplicate := &iotago.UTXOInput{TransactionID: inputUTXO1.TransactionID, TransactionOutputIndex: inputUTXO1.TransactionOutputIndex}
+
+			builder := iotago.NewTransactionBuilder().
+				AddInputs(
+					&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1},
+					&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: duplicate},
+				).
+				AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 50})
+
+			return test{
+				name:       "err - duplicated input",
+				addrSigner: iotago.NewInMemoryAddressSigner(addrKeys),
+				builder:    builder,
+				buildErr:   iotago.ErrTransactionBuilderDuplicatedInput,
+			}
+		}(),
+		func() test {
+			outputAddr1, _ := tpkg.RandEd25519Address()
+			builder := iotago.NewTransactionBuilder().
+				AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 50})
+			for i := 0; i < iotago.MaxInputsCount+1; i++ {
+				inputUTXO, _ := tpkg.RandUTXOInput()
+				builder.AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO})
+			}
+
+			return test{
+				name:       "err - too many inputs",
+				addrSigner: iotago.NewInMemoryAddressSigner(addrKeys),
+				builder:    builder,
+				buildErr:   iotago.ErrTransactionBuilderTooManyInputs,
+			}
+		}(),
+		func() test {
+			inputUTXO1, _ := tpkg.RandUTXOInput()
+			builder := iotago.NewTransactionBuilder().
+				AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1})
+			for i := 0; i < iotago.MaxOutputsCount+1; i++ {
+				outputAddr, _ := tpkg.RandEd25519Address()
+				builder.AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 50})
+			}
+
+			return test{
+				name:       "err - too many outputs",
+				addrSigner: iotago.NewInMemoryAddressSigner(addrKeys),
+				builder:    builder,
+				buildErr:   iotago.ErrTransactionBuilderTooManyOutputs,
+			}
+		}(),
+		func() test {
+			outputAddr1, _ := tpkg.RandEd25519Address()
+			inputUTXO1 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+
+			builder := iotago.NewTransactionBuilder().
+				AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+				AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 50}).
+				AddIndexationPayload(&iotago.Indexation{Index: []byte("index"), Data: nil}).
+				AddIndexationPayload(&iotago.Indexation{Index: []byte("another index"), Data: nil})
+
+			return test{
+				name:       "err - indexation payload added twice",
+				addrSigner: iotago.NewInMemoryAddressSigner(addrKeys),
+				builder:    builder,
+				buildErr:   iotago.ErrTransactionBuilder,
+			}
+		}(),
+		func() test {
+			outputAddr1, _ := tpkg.RandEd25519Address()
+			inputUTXO1, _ := tpkg.RandUTXOInput()
+
+			builder := iotago.NewTransactionBuilder().
+				AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+				AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 50}).
+				AddIndexationPayload(&iotago.Indexation{Index: nil, Data: []byte("data")})
+
+			return test{
+				name:       "err - indexation payload without index",
+				addrSigner: iotago.NewInMemoryAddressSigner(addrKeys),
+				builder:    builder,
+				buildErr:   iotago.ErrIndexationIndexUnderMinSize,
+			}
+		}(),
+		func() test {
+			outputAddr1, _ := tpkg.RandEd25519Address()
+			inputUTXO1, _ := tpkg.RandUTXOInput()
+
+			builder := iotago.NewTransactionBuilder().
+				AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+				AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 50}).
+				AddIndexationPayload(nil)
+
+			return test{
+				name:       "err - nil indexation payload",
+				addrSigner: iotago.NewInMemoryAddressSigner(addrKeys),
+				builder:    builder,
+				buildErr:   iotago.ErrTransactionBuilderInvalidEssencePayload,
+			}
+		}(),
+		func() test {
+			outputAddr1, _ := tpkg.RandEd25519Address()
+			inputUTXO1, _ := tpkg.RandUTXOInput()
+
+			builder := iotago.NewTransactionBuilder().
+				AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+				AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 50}).
+				AddIndexationPayload(&iotago.Indexation{Index: tpkg.RandBytes(iotago.IndexationIndexMaxLength + 1)})
+
+			return test{
+				name:       "err - indexation payload index too long",
+				addrSigner: iotago.NewInMemoryAddressSigner(addrKeys),
+				builder:    builder,
+				buildErr:   iotago.ErrIndexationIndexExceedsMaxSize,
+			}
+		}(),
+		func() test {
+			outputAddr1, _ := tpkg.RandEd25519Address()
+			inputUTXO1, _ := tpkg.RandUTXOInput()
+
+			builder := iotago.NewTransactionBuilder().
+				AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+				AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 50}).
+				AddIndexationPayload(&iotago.Indexation{Index: []byte("index"), Data: tpkg.RandBytes(iotago.MessageBinSerializedMaxSize)})
+
+			return test{
+				name:       "err - indexation payload too big",
+				addrSigner: iotago.NewInMemoryAddressSigner(addrKeys),
+				builder:    builder,
+				buildErr:   iotago.ErrTransactionBuilder,
+			}
+		}(),
+		func() test {
+			outputAddr1, _ := tpkg.RandEd25519Address()
+			inputUTXO1, _ := tpkg.RandUTXOInput()
+
+			builder := iotago.NewTransactionBuilder().
+				AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+				AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 50}).
+				AddIndexationData([]byte("memo"), []byte("data"))
+
+			return test{
+				name:       "ok - with indexation data",
+				addrSigner: iotago.NewInMemoryAddressSigner(addrKeys),
+				builder:    builder,
+			}
+		}(),
+		func() test {
+			outputAddr1, _ := tpkg.RandEd25519Address()
+			inputUTXO1, _ := tpkg.RandUTXOInput()
+
+			builder := iotago.NewTransactionBuilder().
+				AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+				AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 50}).
+				AddIndexationData(tpkg.RandBytes(iotago.IndexationIndexMaxLength+1), []byte("data"))
+
+			return test{
+				name:       "err - indexation data index too long",
+				addrSigner: iotago.NewInMemoryAddressSigner(addrKeys),
+				builder:    builder,
+				buildErr:   iotago.ErrIndexationIndexExceedsMaxSize,
+			}
+		}(),
 		func() test {
 			builder := iotago.NewTransactionBuilder()
 			return test{
 				name:       "err - no inputs",
 				addrSigner: nil,
 				builder:    builder,
-				buildErr:   iotago.ErrMinInputsNotReached,
+				buildErr:   iotago.ErrTransactionBuilderNoInputs,
 			}
 		}(),
 		func() test {
@@ -69,7 +258,7 @@ func TestTransactionBuilder(t *testing.T) {
 				name:       "err - no outputs",
 				addrSigner: nil,
 				builder:    builder,
-				buildErr:   iotago.ErrMinOutputsNotReached,
+				buildErr:   iotago.ErrTransactionBuilderNoOutputs,
 			}
 		}(),
 		func() test {
@@ -120,3 +309,1625 @@ func TestTransactionBuilder(t *testing.T) {
 		})
 	}
 }
+
+// mocks the node API to return the given output for the given UTXO input.
+func mockNodeOutput(t *testing.T, utxoInput *iotago.UTXOInput, output iotago.Output) {
+	outputJson, err := output.MarshalJSON()
+	require.NoError(t, err)
+	rawMsgOutputJson := json.RawMessage(outputJson)
+
+	utxoInputID := utxoInput.ID()
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteOutput, utxoInputID.ToHex())).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.NodeOutputResponse{
+			TransactionID: fmt.Sprintf("%x", utxoInput.TransactionID),
+			OutputIndex:   utxoInput.TransactionOutputIndex,
+			RawOutput:     &rawMsgOutputJson,
+		}})
+}
+
+// mocks the node API to return the given outputs as the unspent outputs of the given address.
+func mockNodeAddressOutputs(t *testing.T, addr *iotago.Ed25519Address, outputs map[*iotago.UTXOInput]iotago.Output) {
+	res := &iotago.AddressOutputsResponse{Address: addr.String(), OutputIDs: []iotago.OutputIDHex{}}
+	for utxoInput, output := range outputs {
+		utxoInputID := utxoInput.ID()
+		res.OutputIDs = append(res.OutputIDs, iotago.OutputIDHex(utxoInputID.ToHex()))
+		mockNodeOutput(t, utxoInput, output)
+	}
+	res.Count = uint32(len(res.OutputIDs))
+
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteAddressEd25519Outputs, addr.String())).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: res})
+}
+
+func TestTransactionBuilder_BuildWithDustValidation(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: identityOne}
+
+	type test struct {
+		name string
+		mock func(t *testing.T, dustAddr *iotago.Ed25519Address)
+		// whether the transaction also creates a dust allowance output on the dust address
+		newAllowance bool
+		buildErr     error
+	}
+
+	// mocks a dust allowance output on the dust address next to the given amount of existing dust outputs
+	mockAllowanceWithDust := func(existingDust int) func(t *testing.T, dustAddr *iotago.Ed25519Address) {
+		return func(t *testing.T, dustAddr *iotago.Ed25519Address) {
+			outputs := map[*iotago.UTXOInput]iotago.Output{}
+			allowanceUTXO, _ := tpkg.RandUTXOInput()
+			outputs[allowanceUTXO] = &iotago.SigLockedDustAllowanceOutput{Address: dustAddr, Amount: iotago.OutputSigLockedDustAllowanceOutputMinDeposit}
+			for i := 0; i < existingDust; i++ {
+				dustUTXO, _ := tpkg.RandUTXOInput()
+				outputs[dustUTXO] = &iotago.SigLockedSingleOutput{Address: dustAddr, Amount: 1}
+			}
+			mockNodeAddressOutputs(t, dustAddr, outputs)
+		}
+	}
+	allowedDust := int(int64(iotago.OutputSigLockedDustAllowanceOutputMinDeposit) / iotago.DustAllowanceDivisor)
+
+	tests := []test{
+		{
+			name: "ok - dust output covered by allowance",
+			mock: func(t *testing.T, dustAddr *iotago.Ed25519Address) {
+				allowanceUTXO, _ := tpkg.RandUTXOInput()
+				mockNodeAddressOutputs(t, dustAddr, map[*iotago.UTXOInput]iotago.Output{
+					allowanceUTXO: &iotago.SigLockedDustAllowanceOutput{Address: dustAddr, Amount: iotago.OutputSigLockedDustAllowanceOutputMinDeposit},
+				})
+			},
+		},
+		{
+			name: "err - dust output without allowance",
+			mock: func(t *testing.T, dustAddr *iotago.Ed25519Address) {
+				mockNodeAddressOutputs(t, dustAddr, map[*iotago.UTXOInput]iotago.Output{})
+			},
+			buildErr: iotago.ErrTransactionBuilderDustViolation,
+		},
+		{
+			name: "err - allowance already exhausted by existing dust outputs",
+			mock: func(t *testing.T, dustAddr *iotago.Ed25519Address) {
+				outputs := map[*iotago.UTXOInput]iotago.Output{}
+				allowanceUTXO, _ := tpkg.RandUTXOInput()
+				outputs[allowanceUTXO] = &iotago.SigLockedDustAllowanceOutput{Address: dustAddr, Amount: iotago.OutputSigLockedDustAllowanceOutputMinDeposit}
+				allowed := int(int64(iotago.OutputSigLockedDustAllowanceOutputMinDeposit) / iotago.DustAllowanceDivisor)
+				for i := 0; i < allowed; i++ {
+					dustUTXO, _ := tpkg.RandUTXOInput()
+					outputs[dustUTXO] = &iotago.SigLockedSingleOutput{Address: dustAddr, Amount: 1}
+				}
+				mockNodeAddressOutputs(t, dustAddr, outputs)
+			},
+			buildErr: iotago.ErrTransactionBuilderDustViolation,
+		},
+		{
+			name:         "ok - dust outputs counted together with a new allowance",
+			mock:         mockAllowanceWithDust(allowedDust),
+			newAllowance: true,
+		},
+		{
+			name:         "err - dust outputs counted together exceed a new allowance",
+			mock:         mockAllowanceWithDust(2 * allowedDust),
+			newAllowance: true,
+			buildErr:     iotago.ErrTransactionBuilderDustViolation,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer gock.Off()
+
+			dustAddr, _ := tpkg.RandEd25519Address()
+			outputAddr, _ := tpkg.RandEd25519Address()
+			inputUTXO, _ := tpkg.RandUTXOInput()
+			mockNodeOutput(t, inputUTXO, &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 5_000_000})
+			test.mock(t, dustAddr)
+
+			remainder := uint64(5_000_000 - 100)
+			builder := iotago.NewTransactionBuilder().
+				AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO}).
+				AddOutput(&iotago.SigLockedSingleOutput{Address: dustAddr, Amount: 100})
+			if test.newAllowance {
+				builder.AddOutput(&iotago.SigLockedDustAllowanceOutput{Address: dustAddr, Amount: iotago.OutputSigLockedDustAllowanceOutputMinDeposit})
+				remainder -= iotago.OutputSigLockedDustAllowanceOutputMinDeposit
+			}
+			builder.AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: remainder})
+
+			nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+			_, err := builder.BuildWithDustValidation(context.Background(), nodeAPI, iotago.NewInMemoryAddressSigner(addrKeys))
+			if test.buildErr != nil {
+				assert.True(t, errors.Is(err, test.buildErr))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTransactionBuilder_BuildTreasuryTransaction(t *testing.T) {
+	type test struct {
+		name     string
+		builder  *iotago.TransactionBuilder
+		buildErr error
+	}
+
+	tests := []test{
+		func() test {
+			treasuryInput, _ := tpkg.RandTreasuryInput()
+			builder := iotago.NewTransactionBuilder().
+				AddTreasuryInput(treasuryInput).
+				AddTreasuryOutput(&iotago.TreasuryOutput{Amount: 1337})
+			return test{
+				name:    "ok - treasury input/output",
+				builder: builder,
+			}
+		}(),
+		func() test {
+			builder := iotago.NewTransactionBuilder().
+				AddTreasuryOutput(&iotago.TreasuryOutput{Amount: 1337})
+			return test{
+				name:     "err - missing treasury input",
+				builder:  builder,
+				buildErr: iotago.ErrTransactionBuilder,
+			}
+		}(),
+		func() test {
+			treasuryInput, _ := tpkg.RandTreasuryInput()
+			utxoInput, _ := tpkg.RandUTXOInput()
+			inputAddr, _ := tpkg.RandEd25519Address()
+			builder := iotago.NewTransactionBuilder().
+				AddInput(&iotago.ToBeSignedUTXOInput{Address: inputAddr, Input: utxoInput}).
+				AddTreasuryInput(treasuryInput).
+				AddTreasuryOutput(&iotago.TreasuryOutput{Amount: 1337})
+			return test{
+				name:     "err - mixed with UTXO input",
+				builder:  builder,
+				buildErr: iotago.ErrTransactionBuilder,
+			}
+		}(),
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.builder.BuildTreasuryTransaction()
+			if test.buildErr != nil {
+				assert.True(t, errors.Is(err, test.buildErr))
+				return
+			}
+			assert.NoError(t, err)
+
+			_, err = test.builder.Build(iotago.NewInMemoryAddressSigner())
+			assert.True(t, errors.Is(err, iotago.ErrTransactionBuilder))
+		})
+	}
+}
+
+func TestTransactionBuilder_WithInputsOutputsSortMode(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: identityOne}
+
+	newBuilder := func() *iotago.TransactionBuilder {
+		outputAddr, _ := tpkg.RandEd25519Address()
+		// added in reverse lexical order
+		inputUTXO1 := &iotago.UTXOInput{TransactionID: [32]byte{0xff}, TransactionOutputIndex: 0}
+		inputUTXO2 := &iotago.UTXOInput{TransactionID: [32]byte{0x00}, TransactionOutputIndex: 0}
+		return iotago.NewTransactionBuilder().
+			AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+			AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO2}).
+			AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 50})
+	}
+
+	tx, err := newBuilder().Build(iotago.NewInMemoryAddressSigner(addrKeys))
+	require.NoError(t, err)
+	_, err = tx.Serialize(serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+
+	tx, err = newBuilder().
+		WithInputsOutputsSortMode(iotago.TransactionBuilderSortModeNone).
+		Build(iotago.NewInMemoryAddressSigner(addrKeys))
+	require.NoError(t, err)
+	require.EqualValues(t, [32]byte{0xff}, tx.Essence.(*iotago.TransactionEssence).Inputs[0].(*iotago.UTXOInput).TransactionID)
+	_, err = tx.Serialize(serializer.DeSeriModePerformValidation)
+	require.Error(t, err)
+}
+
+func TestTransactionBuilder_InputSum(t *testing.T) {
+	inputAddr, _ := tpkg.RandEd25519Address()
+	inputUTXO1, _ := tpkg.RandUTXOInput()
+	inputUTXO2, _ := tpkg.RandUTXOInput()
+
+	builder := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: inputAddr, Input: inputUTXO1, Output: &iotago.SigLockedSingleOutput{Address: inputAddr, Amount: 1337}}).
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: inputAddr, Input: inputUTXO2, Output: &iotago.SigLockedDustAllowanceOutput{Address: inputAddr, Amount: 1_000_000}})
+
+	sum, err := builder.InputSum()
+	require.NoError(t, err)
+	require.EqualValues(t, 1_001_337, sum)
+
+	inputUTXO3, _ := tpkg.RandUTXOInput()
+	builder.AddInput(&iotago.ToBeSignedUTXOInput{Address: inputAddr, Input: inputUTXO3})
+	_, err = builder.InputSum()
+	require.True(t, errors.Is(err, iotago.ErrTransactionBuilder))
+}
+
+func TestTransactionBuilder_AddRemainderOutput(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: identityOne}
+
+	type test struct {
+		name             string
+		remainderMode    iotago.TransactionBuilderRemainderMode
+		outputAmount     uint64
+		expectedOutputs  int
+		expectedBuildErr error
+	}
+
+	tests := []test{
+		{name: "ok - with remainder", outputAmount: 400, expectedOutputs: 2},
+		{name: "ok - no remainder", outputAmount: 1000, expectedOutputs: 1},
+		{name: "ok - no remainder with error if dust mode", remainderMode: iotago.TransactionBuilderRemainderModeErrorIfDust, outputAmount: 1000, expectedOutputs: 1},
+		{name: "err - outputs exceed inputs", outputAmount: 1001, expectedBuildErr: iotago.ErrTransactionBuilder},
+		{name: "err - dust remainder with error if dust mode", remainderMode: iotago.TransactionBuilderRemainderModeErrorIfDust, outputAmount: 400, expectedBuildErr: iotago.ErrTransactionBuilder},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			outputAddr, _ := tpkg.RandEd25519Address()
+			remainderAddr, _ := tpkg.RandEd25519Address()
+			inputUTXO, _ := tpkg.RandUTXOInput()
+
+			builder := iotago.NewTransactionBuilder().
+				AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO, Output: &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 1000}}).
+				AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: test.outputAmount}).
+				AddRemainderOutput(remainderAddr).
+				WithRemainderMode(test.remainderMode)
+
+			tx, err := builder.Build(iotago.NewInMemoryAddressSigner(addrKeys))
+			if test.expectedBuildErr != nil {
+				assert.True(t, errors.Is(err, test.expectedBuildErr))
+				return
+			}
+			require.NoError(t, err)
+
+			outputs := tx.Essence.(*iotago.TransactionEssence).Outputs
+			require.Len(t, outputs, test.expectedOutputs)
+			for _, output := range outputs {
+				if output.(*iotago.SigLockedSingleOutput).Address.(*iotago.Ed25519Address).String() == remainderAddr.String() {
+					require.EqualValues(t, 1000-test.outputAmount, output.(*iotago.SigLockedSingleOutput).Amount)
+				}
+			}
+
+			// building again must not add a second remainder output
+			tx, err = builder.Build(iotago.NewInMemoryAddressSigner(addrKeys))
+			require.NoError(t, err)
+			require.Len(t, tx.Essence.(*iotago.TransactionEssence).Outputs, test.expectedOutputs)
+		})
+	}
+}
+
+// an Address implementation unknown to the library.
+type unknownAddress struct {
+	iotago.Ed25519Address
+}
+
+func (u *unknownAddress) Type() iotago.AddressType {
+	return 0xff
+}
+
+var errAddressSerialization = errors.New("address serialization failed")
+
+type unserializableAddress struct {
+	iotago.Ed25519Address
+}
+
+func (u *unserializableAddress) Serialize(serializer.DeSerializationMode) ([]byte, error) {
+	return nil, errAddressSerialization
+}
+
+func TestTransactionBuilder_AddInputsViaNodeQuery(t *testing.T) {
+	defer gock.Off()
+
+	addr, _ := tpkg.RandEd25519Address()
+	utxoInput1, _ := tpkg.RandUTXOInput()
+	utxoInput2, _ := tpkg.RandUTXOInput()
+	outputs := map[*iotago.UTXOInput]iotago.Output{
+		utxoInput1: &iotago.SigLockedSingleOutput{Address: addr, Amount: 1000},
+		utxoInput2: &iotago.SigLockedSingleOutput{Address: addr, Amount: 337},
+	}
+	mockNodeAddressOutputs(t, addr, outputs)
+
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+	builder := iotago.NewTransactionBuilder().AddInputsViaNodeQuery(context.Background(), addr, nodeAPI, nil)
+	require.NoError(t, builder.Err())
+
+	sum, err := builder.InputSum()
+	require.NoError(t, err)
+	require.EqualValues(t, 1337, sum)
+
+	// cancelling the context within the filter stops adding further inputs
+	for i := 0; i < 3; i++ {
+		utxoInput, _ := tpkg.RandUTXOInput()
+		outputs[utxoInput] = &iotago.SigLockedSingleOutput{Address: addr, Amount: 1}
+	}
+	mockNodeAddressOutputs(t, addr, outputs)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var filtered int
+	builder = iotago.NewTransactionBuilder().AddInputsViaNodeQuery(ctx, addr, nodeAPI, func(utxoInput *iotago.UTXOInput, input iotago.Output) bool {
+		filtered++
+		cancel()
+		return true
+	})
+	require.Equal(t, 1, filtered)
+	_, err = builder.Build(iotago.NewInMemoryAddressSigner())
+	require.True(t, errors.Is(err, context.Canceled))
+
+	// a response which hit the node's max results limit is likely incomplete
+	truncatedRes := &iotago.AddressOutputsResponse{Address: addr.String(), MaxResults: 2, Count: 2}
+	for _, utxoInput := range []*iotago.UTXOInput{utxoInput1, utxoInput2} {
+		utxoInputID := utxoInput.ID()
+		truncatedRes.OutputIDs = append(truncatedRes.OutputIDs, iotago.OutputIDHex(utxoInputID.ToHex()))
+		mockNodeOutput(t, utxoInput, outputs[utxoInput])
+	}
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteAddressEd25519Outputs, addr.String())).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: truncatedRes})
+
+	builder = iotago.NewTransactionBuilder().AddInputsViaNodeQuery(context.Background(), addr, nodeAPI, nil)
+	require.True(t, errors.Is(builder.Err(), iotago.ErrTransactionBuilder))
+
+	builder = iotago.NewTransactionBuilder().AddInputsViaNodeQuery(context.Background(), &unknownAddress{}, nodeAPI, nil)
+	require.True(t, errors.Is(builder.Err(), iotago.ErrTransactionBuilderUnsupportedAddress))
+	_, err = builder.Build(iotago.NewInMemoryAddressSigner())
+	require.True(t, errors.Is(err, iotago.ErrTransactionBuilderUnsupportedAddress))
+}
+
+func TestTransactionBuilder_AddInputsViaNodeQuery_SkipsSpentOutputs(t *testing.T) {
+	defer gock.Off()
+
+	addr, _ := tpkg.RandEd25519Address()
+	unspentUTXO, _ := tpkg.RandUTXOInput()
+	spentUTXO, _ := tpkg.RandUTXOInput()
+
+	// the output got spent in between querying the output IDs and the output
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteAddressEd25519Outputs, addr.String())).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.AddressOutputsResponse{
+			Address: addr.String(),
+			Count:   2,
+			OutputIDs: []iotago.OutputIDHex{
+				iotago.OutputIDHex(unspentUTXO.ID().ToHex()),
+				iotago.OutputIDHex(spentUTXO.ID().ToHex()),
+			},
+		}})
+	mockNodeOutput(t, unspentUTXO, &iotago.SigLockedSingleOutput{Address: addr, Amount: 1000})
+	mockNodeSpentOutput(t, spentUTXO, &iotago.SigLockedSingleOutput{Address: addr, Amount: 337}, 42)
+
+	builder := iotago.NewTransactionBuilder().AddInputsViaNodeQuery(context.Background(), addr, iotago.NewNodeHTTPAPIClient(nodeAPIUrl), nil)
+	require.NoError(t, builder.Err())
+	require.True(t, gock.IsDone())
+
+	sum, err := builder.InputSum()
+	require.NoError(t, err)
+	require.EqualValues(t, 1000, sum)
+}
+
+func TestTransactionBuilder_AddInputsViaNodeQueryMulti(t *testing.T) {
+	defer gock.Off()
+
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+
+	mockAddrs := func(amounts ...uint64) []iotago.Address {
+		addrs := make([]iotago.Address, len(amounts))
+		for i, amount := range amounts {
+			addr, _ := tpkg.RandEd25519Address()
+			utxoInput, _ := tpkg.RandUTXOInput()
+			mockNodeAddressOutputs(t, addr, map[*iotago.UTXOInput]iotago.Output{
+				utxoInput: &iotago.SigLockedSingleOutput{Address: addr, Amount: amount},
+			})
+			addrs[i] = addr
+		}
+		return addrs
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		addrs := mockAddrs(1000, 300, 37)
+		builder := iotago.NewTransactionBuilder().AddInputsViaNodeQueryMulti(context.Background(), addrs, nodeAPI, nil, 2)
+		require.NoError(t, builder.Err())
+		require.True(t, gock.IsDone())
+
+		sum, err := builder.InputSum()
+		require.NoError(t, err)
+		require.EqualValues(t, 1337, sum)
+	})
+
+	t.Run("failed addresses", func(t *testing.T) {
+		addrs := mockAddrs(1000)
+		failingAddr, _ := tpkg.RandEd25519Address()
+		gock.New(nodeAPIUrl).
+			Get(fmt.Sprintf(iotago.NodeAPIRouteAddressEd25519Outputs, failingAddr.String())).
+			Reply(500).
+			JSON(&iotago.HTTPErrorResponseEnvelope{})
+		addrs = append(addrs, &unknownAddress{}, failingAddr)
+
+		builder := iotago.NewTransactionBuilder().AddInputsViaNodeQueryMulti(context.Background(), addrs, nodeAPI, nil, 0)
+		require.True(t, errors.Is(builder.Err(), iotago.ErrTransactionBuilderUnsupportedAddress))
+		require.Contains(t, builder.Err().Error(), "2 of 3 addresses")
+		require.Contains(t, builder.Err().Error(), failingAddr.String())
+
+		sum, err := builder.InputSum()
+		require.NoError(t, err)
+		require.Zero(t, sum)
+	})
+
+	t.Run("stops at inputs limit", func(t *testing.T) {
+		addrs := mockAddrs(1000, 1000, 1000)
+		builder := iotago.NewTransactionBuilder().
+			WithNodeQueryInputsLimit(1, false).
+			AddInputsViaNodeQueryMulti(context.Background(), addrs, nodeAPI, nil, 1)
+		require.NoError(t, builder.Err())
+
+		sum, err := builder.InputSum()
+		require.NoError(t, err)
+		require.EqualValues(t, 1000, sum)
+	})
+}
+
+func TestTransactionBuilder_SelectInputs(t *testing.T) {
+	defer gock.Off()
+
+	addr, _ := tpkg.RandEd25519Address()
+	outputs := map[*iotago.UTXOInput]iotago.Output{}
+	for _, amount := range []uint64{1_000_000, 2_000_000, 5_000_000} {
+		utxoInput, _ := tpkg.RandUTXOInput()
+		outputs[utxoInput] = &iotago.SigLockedSingleOutput{Address: addr, Amount: amount}
+	}
+
+	type test struct {
+		name         string
+		targetAmount uint64
+		strategy     iotago.TransactionBuilderInputSelectionStrategy
+		sum          uint64
+		err          error
+	}
+
+	tests := []test{
+		{
+			name:         "ok - largest first",
+			targetAmount: 6_000_000,
+			strategy:     iotago.TransactionBuilderInputSelectionLargestFirst,
+			sum:          7_000_000,
+		},
+		{
+			name:         "ok - smallest first",
+			targetAmount: 3_000_000,
+			strategy:     iotago.TransactionBuilderInputSelectionSmallestFirst,
+			sum:          3_000_000,
+		},
+		{
+			name:         "ok - further output selected to avoid dust remainder",
+			targetAmount: 2_500_000,
+			strategy:     iotago.TransactionBuilderInputSelectionSmallestFirst,
+			sum:          8_000_000,
+		},
+		{
+			name:         "err - insufficient unspent outputs",
+			targetAmount: 9_000_000,
+			strategy:     iotago.TransactionBuilderInputSelectionLargestFirst,
+			err:          iotago.ErrTransactionBuilderInsufficientInputs,
+		},
+		{
+			name:         "err - unknown strategy",
+			targetAmount: 1_000_000,
+			strategy:     iotago.TransactionBuilderInputSelectionSmallestFirst + 1,
+			err:          iotago.ErrTransactionBuilder,
+		},
+	}
+
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNodeAddressOutputs(t, addr, outputs)
+
+			builder := iotago.NewTransactionBuilder()
+			sum, err := builder.SelectInputs(context.Background(), nodeAPI, addr, tt.targetAmount, tt.strategy)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualValues(t, tt.sum, sum)
+
+			inputSum, err := builder.InputSum()
+			assert.NoError(t, err)
+			assert.EqualValues(t, tt.sum, inputSum)
+		})
+	}
+}
+
+func TestTransactionBuilder_SelectInputs_SkipsAddedInputs(t *testing.T) {
+	defer gock.Off()
+
+	addr, _ := tpkg.RandEd25519Address()
+	addedUTXO, _ := tpkg.RandUTXOInput()
+	outputs := map[*iotago.UTXOInput]iotago.Output{
+		addedUTXO: &iotago.SigLockedSingleOutput{Address: addr, Amount: 5_000_000},
+	}
+	for _, amount := range []uint64{1_000_000, 2_000_000} {
+		utxoInput, _ := tpkg.RandUTXOInput()
+		outputs[utxoInput] = &iotago.SigLockedSingleOutput{Address: addr, Amount: amount}
+	}
+	mockNodeAddressOutputs(t, addr, outputs)
+
+	// the input is added without its output, hence the builder does not know its deposit
+	builder := iotago.NewTransactionBuilder().AddInput(&iotago.ToBeSignedUTXOInput{Address: addr, Input: addedUTXO})
+
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+	sum, err := builder.SelectInputs(context.Background(), nodeAPI, addr, 3_000_000, iotago.TransactionBuilderInputSelectionLargestFirst)
+	require.NoError(t, err)
+	require.EqualValues(t, 3_000_000, sum)
+	require.NoError(t, builder.Err())
+
+	essence, _, err := builder.WithInputsOutputsSortMode(iotago.TransactionBuilderSortModeNone).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: addr, Amount: 8_000_000}).
+		BuildEssence()
+	require.NoError(t, err)
+	require.Len(t, essence.Inputs, 3)
+}
+
+func TestTransactionBuilder_WithSignerForAddress(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr1 := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys1 := iotago.AddressKeys{Address: &inputAddr1, Keys: identityOne}
+
+	identityTwo := tpkg.RandEd25519PrivateKey()
+	inputAddr2 := iotago.AddressFromEd25519PubKey(identityTwo.Public().(ed25519.PublicKey))
+	addrKeys2 := iotago.AddressKeys{Address: &inputAddr2, Keys: identityTwo}
+
+	newBuilder := func() *iotago.TransactionBuilder {
+		outputAddr, _ := tpkg.RandEd25519Address()
+		inputUTXO1, _ := tpkg.RandUTXOInput()
+		inputUTXO2, _ := tpkg.RandUTXOInput()
+		return iotago.NewTransactionBuilder().
+			AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr1, Input: inputUTXO1}).
+			AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr2, Input: inputUTXO2}).
+			AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 50})
+	}
+
+	// per address signer plus fallback
+	_, err := newBuilder().
+		WithSignerForAddress(&inputAddr2, iotago.NewInMemoryAddressSigner(addrKeys2)).
+		Build(iotago.NewInMemoryAddressSigner(addrKeys1))
+	require.NoError(t, err)
+
+	// only per address signers
+	_, err = newBuilder().
+		WithSignerForAddress(&inputAddr1, iotago.NewInMemoryAddressSigner(addrKeys1)).
+		WithSignerForAddress(&inputAddr2, iotago.NewInMemoryAddressSigner(addrKeys2)).
+		Build(nil)
+	require.NoError(t, err)
+
+	// no signer for the second address
+	_, err = newBuilder().
+		WithSignerForAddress(&inputAddr1, iotago.NewInMemoryAddressSigner(addrKeys1)).
+		Build(nil)
+	require.True(t, errors.Is(err, iotago.ErrTransactionBuilder))
+}
+
+func TestTransactionBuilder_BuildEssence(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+
+	outputAddr, _ := tpkg.RandEd25519Address()
+	inputUTXO1 := &iotago.UTXOInput{TransactionID: [32]byte{0xff}, TransactionOutputIndex: 0}
+	inputUTXO2 := &iotago.UTXOInput{TransactionID: [32]byte{0x00}, TransactionOutputIndex: 0}
+
+	essence, signingMsg, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO2}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 50}).
+		BuildEssence()
+	require.NoError(t, err)
+
+	// sorted
+	require.EqualValues(t, inputUTXO2, essence.Inputs[0])
+	require.EqualValues(t, inputUTXO1, essence.Inputs[1])
+
+	expectedSigningMsg, err := essence.SigningMessage()
+	require.NoError(t, err)
+	require.EqualValues(t, expectedSigningMsg, signingMsg)
+
+	_, _, err = iotago.NewTransactionBuilder().BuildEssence()
+	require.True(t, errors.Is(err, iotago.ErrMinInputsNotReached))
+	require.True(t, errors.Is(err, iotago.ErrTransactionBuilderNoInputs))
+}
+
+func TestTransactionBuilder_BuildEssence_NoInputsOutputs(t *testing.T) {
+	for _, sortMode := range []iotago.TransactionBuilderSortMode{iotago.TransactionBuilderSortModeLexical, iotago.TransactionBuilderSortModeNone} {
+		_, _, err := iotago.NewTransactionBuilder().WithInputsOutputsSortMode(sortMode).BuildEssence()
+		require.True(t, errors.Is(err, iotago.ErrTransactionBuilderNoInputs))
+		require.True(t, errors.Is(err, iotago.ErrTransactionBuilder))
+		require.True(t, errors.Is(err, iotago.ErrMinInputsNotReached))
+
+		addr, _ := tpkg.RandEd25519Address()
+		inputUTXO, _ := tpkg.RandUTXOInput()
+		_, _, err = iotago.NewTransactionBuilder().
+			WithInputsOutputsSortMode(sortMode).
+			AddInput(&iotago.ToBeSignedUTXOInput{Address: addr, Input: inputUTXO}).
+			BuildEssence()
+		require.True(t, errors.Is(err, iotago.ErrTransactionBuilderNoOutputs))
+		require.True(t, errors.Is(err, iotago.ErrTransactionBuilder))
+		require.True(t, errors.Is(err, iotago.ErrMinOutputsNotReached))
+	}
+
+	require.False(t, errors.Is(iotago.ErrTransactionBuilderNoInputs, iotago.ErrMinOutputsNotReached))
+	require.False(t, errors.Is(iotago.ErrTransactionBuilderNoOutputs, iotago.ErrMinInputsNotReached))
+}
+
+func TestTransactionBuilder_BuildWithUnlockBlocks(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: identityOne}
+
+	newBuilder := func() *iotago.TransactionBuilder {
+		outputAddr, _ := tpkg.RandEd25519Address()
+		inputUTXO1, _ := tpkg.RandUTXOInput()
+		inputUTXO2, _ := tpkg.RandUTXOInput()
+		return iotago.NewTransactionBuilder().
+			AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+			AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO2}).
+			AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 50})
+	}
+
+	sign := func(t *testing.T, builder *iotago.TransactionBuilder) *iotago.SignatureUnlockBlock {
+		_, signingMsg, err := builder.BuildEssence()
+		require.NoError(t, err)
+		sig, err := iotago.NewInMemoryAddressSigner(addrKeys).Sign(&inputAddr, signingMsg)
+		require.NoError(t, err)
+		return &iotago.SignatureUnlockBlock{Signature: sig}
+	}
+
+	type test struct {
+		name         string
+		unlockBlocks func(sigBlock *iotago.SignatureUnlockBlock) serializer.Serializables
+		buildErr     error
+	}
+
+	tests := []test{
+		{
+			name: "ok",
+			unlockBlocks: func(sigBlock *iotago.SignatureUnlockBlock) serializer.Serializables {
+				return serializer.Serializables{sigBlock, &iotago.ReferenceUnlockBlock{Reference: 0}}
+			},
+		},
+		{
+			name: "err - count mismatch",
+			unlockBlocks: func(sigBlock *iotago.SignatureUnlockBlock) serializer.Serializables {
+				return serializer.Serializables{sigBlock}
+			},
+			buildErr: iotago.ErrTransactionBuilder,
+		},
+		{
+			name: "err - signature instead of reference",
+			unlockBlocks: func(sigBlock *iotago.SignatureUnlockBlock) serializer.Serializables {
+				return serializer.Serializables{sigBlock, sigBlock}
+			},
+			buildErr: iotago.ErrTransactionBuilder,
+		},
+		{
+			name: "err - reference first",
+			unlockBlocks: func(sigBlock *iotago.SignatureUnlockBlock) serializer.Serializables {
+				return serializer.Serializables{&iotago.ReferenceUnlockBlock{Reference: 1}, sigBlock}
+			},
+			buildErr: iotago.ErrTransactionBuilder,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			builder := newBuilder()
+			_, err := builder.BuildWithUnlockBlocks(test.unlockBlocks(sign(t, builder)))
+			if test.buildErr != nil {
+				assert.True(t, errors.Is(err, test.buildErr))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTransactionBuilder_Build_UnlockBlocksAddrMismatch(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+
+	// a buggy signer which signs with a key not belonging to the address
+	wrongIdentity := tpkg.RandEd25519PrivateKey()
+	wrongAddr := iotago.AddressFromEd25519PubKey(wrongIdentity.Public().(ed25519.PublicKey))
+	wrongSigner := iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &wrongAddr, Keys: wrongIdentity})
+	buggySigner := iotago.AddressSignerFunc(func(addr iotago.Address, msg []byte) (serializer.Serializable, error) {
+		return wrongSigner.Sign(&wrongAddr, msg)
+	})
+
+	outputAddr, _ := tpkg.RandEd25519Address()
+	inputUTXO, _ := tpkg.RandUTXOInput()
+
+	_, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 50}).
+		Build(buggySigner)
+	require.True(t, errors.Is(err, iotago.ErrTransactionBuilder))
+}
+
+func TestTransactionBuilder_Build_CrossTypeAddressCollision(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	signer := iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &inputAddr, Keys: identityOne})
+
+	// an address of another type sharing the string and key of the Ed25519 address
+	collidingAddr := &unknownAddress{Ed25519Address: inputAddr}
+	require.Equal(t, inputAddr.String(), collidingAddr.String())
+
+	var signedAddrs []iotago.Address
+	recordingSigner := iotago.AddressSignerFunc(func(addr iotago.Address, msg []byte) (serializer.Serializable, error) {
+		signedAddrs = append(signedAddrs, addr)
+		return signer.Sign(&inputAddr, msg)
+	})
+
+	outputAddr, _ := tpkg.RandEd25519Address()
+	inputUTXO1, _ := tpkg.RandUTXOInput()
+	inputUTXO2, _ := tpkg.RandUTXOInput()
+
+	_, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: collidingAddr, Input: inputUTXO2}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 50}).
+		WithInputsOutputsSortMode(iotago.TransactionBuilderSortModeNone).
+		Build(recordingSigner)
+	// the Ed25519 signature can not unlock the colliding address, but both addresses must have been signed
+	// instead of the colliding address being unlocked by a reference to the Ed25519 address' signature
+	require.True(t, errors.Is(err, iotago.ErrTransactionBuilderUnlockBlocksMismatch))
+	require.Len(t, signedAddrs, 2)
+	require.Equal(t, &inputAddr, signedAddrs[0])
+	require.Equal(t, collidingAddr, signedAddrs[1])
+}
+
+func TestTransactionBuilder_Build_UnserializableAddress(t *testing.T) {
+	outputAddr, _ := tpkg.RandEd25519Address()
+	inputUTXO1, _ := tpkg.RandUTXOInput()
+	inputUTXO2, _ := tpkg.RandUTXOInput()
+
+	// two unserializable addresses must not share a key and thereby be unlocked by a single signature
+	_, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &unserializableAddress{}, Input: inputUTXO1}).
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &unserializableAddress{}, Input: inputUTXO2}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 50}).
+		Build(iotago.NewInMemoryAddressSigner())
+	require.True(t, errors.Is(err, iotago.ErrTransactionBuilder))
+	require.Contains(t, err.Error(), errAddressSerialization.Error())
+
+	builder := iotago.NewTransactionBuilder().WithSignerForAddress(&unserializableAddress{}, iotago.NewInMemoryAddressSigner())
+	require.True(t, errors.Is(builder.Err(), iotago.ErrTransactionBuilder))
+
+	builder = iotago.NewTransactionBuilder().AddPaymentOutputs([]iotago.Payment{{Address: &unserializableAddress{}, Amount: 50}}, true)
+	require.True(t, errors.Is(builder.Err(), iotago.ErrTransactionBuilder))
+
+	require.False(t, iotago.AddressesEqual(&unserializableAddress{}, &unserializableAddress{}))
+}
+
+func TestTransactionBuilder_Reset(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: identityOne}
+
+	outputAddr, _ := tpkg.RandEd25519Address()
+	inputUTXO, _ := tpkg.RandUTXOInput()
+
+	builder := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 50}).
+		AddIndexationPayload(&iotago.Indexation{Index: []byte("index")}).
+		AddRemainderOutput(outputAddr).
+		WithSignerForAddress(&inputAddr, iotago.NewInMemoryAddressSigner(addrKeys)).
+		WithInputsOutputsSortMode(iotago.TransactionBuilderSortModeNone).
+		AddInputsViaNodeQuery(context.Background(), &unknownAddress{}, nil, nil)
+
+	require.EqualValues(t, iotago.NewTransactionBuilder(), builder.Reset())
+}
+
+func TestTransactionBuilder_Clone(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: identityOne}
+
+	outputAddr1, _ := tpkg.RandEd25519Address()
+	outputAddr2, _ := tpkg.RandEd25519Address()
+	inputUTXO1, _ := tpkg.RandUTXOInput()
+	inputUTXO2, _ := tpkg.RandUTXOInput()
+
+	builder := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 50})
+
+	clone := builder.Clone()
+	require.EqualValues(t, builder, clone)
+
+	clone.
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO2}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr2, Amount: 50})
+
+	tx, err := builder.Build(iotago.NewInMemoryAddressSigner(addrKeys))
+	require.NoError(t, err)
+	require.Len(t, tx.Essence.(*iotago.TransactionEssence).Inputs, 1)
+	require.Len(t, tx.Essence.(*iotago.TransactionEssence).Outputs, 1)
+
+	clonedTx, err := clone.Build(iotago.NewInMemoryAddressSigner(addrKeys))
+	require.NoError(t, err)
+	require.Len(t, clonedTx.Essence.(*iotago.TransactionEssence).Inputs, 2)
+	require.Len(t, clonedTx.Essence.(*iotago.TransactionEssence).Outputs, 2)
+}
+
+func TestTransactionBuilder_EstimatedSize(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr1 := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	identityTwo := tpkg.RandEd25519PrivateKey()
+	inputAddr2 := iotago.AddressFromEd25519PubKey(identityTwo.Public().(ed25519.PublicKey))
+	signer := iotago.NewInMemoryAddressSigner(
+		iotago.AddressKeys{Address: &inputAddr1, Keys: identityOne},
+		iotago.AddressKeys{Address: &inputAddr2, Keys: identityTwo},
+	)
+
+	outputAddr, _ := tpkg.RandEd25519Address()
+	inputUTXO1, _ := tpkg.RandUTXOInput()
+	inputUTXO2, _ := tpkg.RandUTXOInput()
+	inputUTXO3, _ := tpkg.RandUTXOInput()
+
+	builder := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr1, Input: inputUTXO1}).
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr1, Input: inputUTXO2}).
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr2, Input: inputUTXO3}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 50}).
+		AddIndexationPayload(&iotago.Indexation{Index: []byte("index"), Data: []byte("data")})
+
+	estimatedSize, err := builder.EstimatedSize()
+	require.NoError(t, err)
+
+	tx, err := builder.Build(signer)
+	require.NoError(t, err)
+	txData, err := tx.Serialize(serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+	require.Equal(t, len(txData), estimatedSize)
+}
+
+func TestTransactionBuilder_BuildAndSwapToMessageBuilderWithParents(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: identityOne}
+
+	outputAddr, _ := tpkg.RandEd25519Address()
+	inputUTXO, _ := tpkg.RandUTXOInput()
+	parents := tpkg.SortedRand32BytArray(2)
+
+	msg, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 50}).
+		BuildAndSwapToMessageBuilderWithParents(iotago.NewInMemoryAddressSigner(addrKeys), nil, parents).
+		Build()
+	require.NoError(t, err)
+	require.EqualValues(t, iotago.MessageIDs(parents), msg.Parents)
+	require.IsType(t, &iotago.Transaction{}, msg.Payload)
+}
+
+func TestBuildConsolidation(t *testing.T) {
+	defer gock.Off()
+
+	identity := tpkg.RandEd25519PrivateKey()
+	addr := iotago.AddressFromEd25519PubKey(identity.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &addr, Keys: identity}
+	signer := iotago.NewInMemoryAddressSigner(addrKeys)
+
+	mockInfoAndTips := func() {
+		gock.New(nodeAPIUrl).
+			Get(iotago.NodeAPIRouteInfo).
+			Reply(200).
+			JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.NodeInfoResponse{NetworkID: "alphanet@1"}})
+		gock.New(nodeAPIUrl).
+			Get(iotago.NodeAPIRouteTips).
+			Persist().
+			Reply(200).
+			JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.NodeTipsResponse{
+				TipsHex: []string{fmt.Sprintf("%x", tpkg.Rand32ByteArray())},
+			}})
+	}
+
+	// more outputs than fit into one transaction
+	const amountOutputs = iotago.MaxInputsCount + 3
+	outputs := map[*iotago.UTXOInput]iotago.Output{}
+	for i := 0; i < amountOutputs; i++ {
+		utxoInput, _ := tpkg.RandUTXOInput()
+		outputs[utxoInput] = &iotago.SigLockedSingleOutput{Address: &addr, Amount: 1_000_000}
+	}
+	mockNodeAddressOutputs(t, &addr, outputs)
+	mockInfoAndTips()
+
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+	msgs, err := iotago.BuildConsolidation(context.Background(), nodeAPI, &addr, signer)
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+
+	var consolidatedInputs int
+	for _, msg := range msgs {
+		require.EqualValues(t, iotago.NetworkIDFromString("alphanet@1"), msg.NetworkID)
+		tx := msg.Payload.(*iotago.Transaction)
+		essence := tx.Essence.(*iotago.TransactionEssence)
+		consolidatedInputs += len(essence.Inputs)
+		require.Len(t, essence.Outputs, 1)
+		output := essence.Outputs[0].(*iotago.SigLockedSingleOutput)
+		require.EqualValues(t, &addr, output.Address)
+		require.EqualValues(t, len(essence.Inputs)*1_000_000, output.Amount)
+		_, err := msg.Serialize(serializer.DeSeriModePerformValidation)
+		require.NoError(t, err)
+	}
+	require.Equal(t, amountOutputs, consolidatedInputs)
+	gock.Off()
+
+	// a single output is not consolidated
+	utxoInput, _ := tpkg.RandUTXOInput()
+	mockNodeAddressOutputs(t, &addr, map[*iotago.UTXOInput]iotago.Output{
+		utxoInput: &iotago.SigLockedSingleOutput{Address: &addr, Amount: 1_000_000},
+	})
+	msgs, err = iotago.BuildConsolidation(context.Background(), nodeAPI, &addr, signer)
+	require.NoError(t, err)
+	require.Empty(t, msgs)
+}
+
+func TestTransactionBuilder_WithRentStructure(t *testing.T) {
+	identity := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identity.Public().(ed25519.PublicKey))
+	signer := iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &inputAddr, Keys: identity})
+	outputAddr, _ := tpkg.RandEd25519Address()
+	inputUTXO, _ := tpkg.RandUTXOInput()
+
+	rentStruct := &iotago.RentStructure{VByteCost: 100, VBFactorData: 1, VBFactorKey: 10}
+	minDeposit := rentStruct.MinStorageDeposit(&iotago.SigLockedSingleOutput{Address: outputAddr})
+
+	build := func(amount uint64) error {
+		_, err := iotago.NewTransactionBuilder().
+			WithRentStructure(rentStruct).
+			AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO, Output: &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 2 * minDeposit}}).
+			AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: amount}).
+			AddRemainderOutput(&inputAddr).
+			Build(signer)
+		return err
+	}
+
+	require.NoError(t, build(minDeposit))
+
+	err := build(minDeposit - 1)
+	require.True(t, errors.Is(err, iotago.ErrTransactionBuilderStorageDepositNotCovered))
+	require.True(t, errors.Is(err, iotago.ErrTransactionBuilder))
+	require.Contains(t, err.Error(), fmt.Sprintf("deposits %d but requires %d", minDeposit-1, minDeposit))
+}
+
+func TestTransactionBuilder_WithPostSignVerification(t *testing.T) {
+	identity := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identity.Public().(ed25519.PublicKey))
+	signer := iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &inputAddr, Keys: identity})
+	outputAddr, _ := tpkg.RandEd25519Address()
+	inputUTXO1, _ := tpkg.RandUTXOInput()
+	inputUTXO2, _ := tpkg.RandUTXOInput()
+
+	// produces a signature with the right public key but over the wrong message
+	faultySigner := iotago.AddressSignerFunc(func(addr iotago.Address, msg []byte) (serializer.Serializable, error) {
+		return signer.Sign(addr, append([]byte{1}, msg...))
+	})
+
+	newBuilder := func() *iotago.TransactionBuilder {
+		return iotago.NewTransactionBuilder().
+			AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+			AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO2}).
+			AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 1337})
+	}
+
+	_, err := newBuilder().WithPostSignVerification().Build(signer)
+	require.NoError(t, err)
+
+	// without the verification the faulty signature goes unnoticed
+	_, err = newBuilder().Build(faultySigner)
+	require.NoError(t, err)
+
+	_, err = newBuilder().WithPostSignVerification().Build(faultySigner)
+	require.True(t, errors.Is(err, iotago.ErrTransactionBuilderInvalidSignature))
+}
+
+func TestTransactionBuilder_AddInputsViaNodeQueryWithMetadata(t *testing.T) {
+	defer gock.Off()
+
+	const ledgerIndex = 100
+	addr, _ := tpkg.RandEd25519Address()
+
+	type mockedOutput struct {
+		utxoInput           *iotago.UTXOInput
+		output              iotago.Output
+		referencedAtMsIndex uint32
+	}
+
+	var mockedOutputs []mockedOutput
+	for _, referencedAtMsIndex := range []uint32{10, 99} {
+		utxoInput, _ := tpkg.RandUTXOInput()
+		mockedOutputs = append(mockedOutputs, mockedOutput{
+			utxoInput:           utxoInput,
+			output:              &iotago.SigLockedSingleOutput{Address: addr, Amount: 1000},
+			referencedAtMsIndex: referencedAtMsIndex,
+		})
+	}
+
+	res := &iotago.AddressOutputsResponse{Address: addr.String(), Count: uint32(len(mockedOutputs))}
+	for _, mocked := range mockedOutputs {
+		utxoInputID := mocked.utxoInput.ID()
+		res.OutputIDs = append(res.OutputIDs, iotago.OutputIDHex(utxoInputID.ToHex()))
+
+		outputJson, err := mocked.output.MarshalJSON()
+		require.NoError(t, err)
+		rawMsgOutputJson := json.RawMessage(outputJson)
+		msgID := tpkg.Rand32ByteArray()
+		referencedAtMsIndex := mocked.referencedAtMsIndex
+
+		gock.New(nodeAPIUrl).
+			Get(fmt.Sprintf(iotago.NodeAPIRouteOutput, utxoInputID.ToHex())).
+			Reply(200).
+			JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.NodeOutputResponse{
+				MessageID:     fmt.Sprintf("%x", msgID),
+				TransactionID: fmt.Sprintf("%x", mocked.utxoInput.TransactionID),
+				OutputIndex:   mocked.utxoInput.TransactionOutputIndex,
+				LedgerIndex:   ledgerIndex,
+				RawOutput:     &rawMsgOutputJson,
+			}})
+		gock.New(nodeAPIUrl).
+			Get(fmt.Sprintf(iotago.NodeAPIRouteMessageMetadata, fmt.Sprintf("%x", msgID))).
+			Reply(200).
+			JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.MessageMetadataResponse{
+				MessageID:                  fmt.Sprintf("%x", msgID),
+				ReferencedByMilestoneIndex: &referencedAtMsIndex,
+			}})
+	}
+	gock.New(nodeAPIUrl).
+		Get(fmt.Sprintf(iotago.NodeAPIRouteAddressEd25519Outputs, addr.String())).
+		Reply(200).
+		JSON(&iotago.HTTPOkResponseEnvelope{Data: res})
+
+	// only outputs confirmed at least 5 milestones ago
+	const minConfirmations = 5
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+	builder := iotago.NewTransactionBuilder().AddInputsViaNodeQueryWithMetadata(context.Background(), addr, nodeAPI,
+		func(utxoInput *iotago.UTXOInput, input iotago.Output, metadata *iotago.TransactionBuilderInputMetadata) bool {
+			require.EqualValues(t, ledgerIndex, metadata.LedgerIndex)
+			return metadata.ReferencedByMilestoneIndex != 0 && metadata.LedgerIndex-uint64(metadata.ReferencedByMilestoneIndex) >= minConfirmations
+		})
+	require.NoError(t, builder.Err())
+	require.True(t, gock.IsDone())
+
+	essence, _, err := builder.
+		AddOutput(&iotago.SigLockedSingleOutput{Address: addr, Amount: 1000}).
+		BuildEssence()
+	require.NoError(t, err)
+	require.Len(t, essence.Inputs, 1)
+	require.EqualValues(t, mockedOutputs[0].utxoInput, essence.Inputs[0])
+}
+
+func TestTransactionBuilder_WithNodeQueryInputsLimit(t *testing.T) {
+	defer gock.Off()
+
+	addr, _ := tpkg.RandEd25519Address()
+	outputs := map[*iotago.UTXOInput]iotago.Output{}
+	for _, amount := range []uint64{100, 5000, 300, 4000} {
+		utxoInput, _ := tpkg.RandUTXOInput()
+		outputs[utxoInput] = &iotago.SigLockedSingleOutput{Address: addr, Amount: amount}
+	}
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+
+	// one input is already present, hence only two more are added
+	presentUTXO, _ := tpkg.RandUTXOInput()
+	mockNodeAddressOutputs(t, addr, outputs)
+	builder := iotago.NewTransactionBuilder().
+		WithNodeQueryInputsLimit(3, true).
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: addr, Input: presentUTXO, Output: &iotago.SigLockedSingleOutput{Address: addr, Amount: 1}}).
+		AddInputsViaNodeQuery(context.Background(), addr, nodeAPI, nil)
+	require.NoError(t, builder.Err())
+	require.Equal(t, 2, builder.NodeQuerySkippedInputs())
+
+	sum, err := builder.InputSum()
+	require.NoError(t, err)
+	require.EqualValues(t, 1+5000+4000, sum)
+
+	// the skipped outputs accumulate over queries
+	mockNodeAddressOutputs(t, addr, outputs)
+	builder.AddInputsViaNodeQuery(context.Background(), addr, nodeAPI, nil)
+	require.Equal(t, 6, builder.NodeQuerySkippedInputs())
+
+	// without a limit every output is added
+	mockNodeAddressOutputs(t, addr, outputs)
+	builder = iotago.NewTransactionBuilder().AddInputsViaNodeQuery(context.Background(), addr, nodeAPI, nil)
+	require.Equal(t, 0, builder.NodeQuerySkippedInputs())
+	sum, err = builder.InputSum()
+	require.NoError(t, err)
+	require.EqualValues(t, 100+5000+300+4000, sum)
+}
+
+func TestTransactionBuilder_AddPaymentOutputs(t *testing.T) {
+	addr1, _ := tpkg.RandEd25519Address()
+	addr2, _ := tpkg.RandEd25519Address()
+	payments := []iotago.Payment{
+		{Address: addr1, Amount: 1_000_000},
+		{Address: addr2, Amount: 2_000_000},
+		{Address: addr1, Amount: 337},
+	}
+
+	tests := []struct {
+		name      string
+		payments  []iotago.Payment
+		aggregate bool
+		outputs   []*iotago.SigLockedSingleOutput
+		err       error
+	}{
+		{
+			name:     "ok - one output per payment",
+			payments: payments,
+			outputs: []*iotago.SigLockedSingleOutput{
+				{Address: addr1, Amount: 1_000_000},
+				{Address: addr2, Amount: 2_000_000},
+				{Address: addr1, Amount: 337},
+			},
+		},
+		{
+			name:      "ok - aggregated",
+			payments:  payments,
+			aggregate: true,
+			outputs: []*iotago.SigLockedSingleOutput{
+				{Address: addr1, Amount: 1_000_337},
+				{Address: addr2, Amount: 2_000_000},
+			},
+		},
+		{
+			name:     "err - zero amount",
+			payments: []iotago.Payment{{Address: addr1, Amount: 1_000_000}, {Address: addr2}},
+			err:      iotago.ErrTransactionBuilder,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inputUTXO, _ := tpkg.RandUTXOInput()
+			builder := iotago.NewTransactionBuilder().
+				WithInputsOutputsSortMode(iotago.TransactionBuilderSortModeNone).
+				AddInput(&iotago.ToBeSignedUTXOInput{Address: addr1, Input: inputUTXO}).
+				AddPaymentOutputs(tt.payments, tt.aggregate)
+			if tt.err != nil {
+				assert.True(t, errors.Is(builder.Err(), tt.err))
+				return
+			}
+			require.NoError(t, builder.Err())
+
+			essence, _, err := builder.BuildEssence()
+			require.NoError(t, err)
+			require.Len(t, essence.Outputs, len(tt.outputs))
+			for i, output := range tt.outputs {
+				require.EqualValues(t, output, essence.Outputs[i])
+			}
+		})
+	}
+}
+
+func TestTransactionBuilder_WithNodeQueryTimeout(t *testing.T) {
+	defer gock.Off()
+
+	addr, _ := tpkg.RandEd25519Address()
+	mockSlowNode := func() {
+		gock.New(nodeAPIUrl).
+			Get(fmt.Sprintf(iotago.NodeAPIRouteAddressEd25519Outputs, addr.String())).
+			Reply(200).
+			Delay(time.Second).
+			JSON(&iotago.HTTPOkResponseEnvelope{Data: &iotago.AddressOutputsResponse{Address: addr.String()}})
+	}
+	nodeAPI := iotago.NewNodeHTTPAPIClient(nodeAPIUrl)
+
+	mockSlowNode()
+	builder := iotago.NewTransactionBuilder().
+		WithNodeQueryTimeout(10*time.Millisecond).
+		AddInputsViaNodeQuery(context.Background(), addr, nodeAPI, nil)
+	require.True(t, errors.Is(builder.Err(), context.DeadlineExceeded))
+
+	// the deadline of the given context takes precedence
+	mockSlowNode()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	builder = iotago.NewTransactionBuilder().
+		WithNodeQueryTimeout(10*time.Millisecond).
+		AddInputsViaNodeQuery(ctx, addr, nodeAPI, nil)
+	require.NoError(t, builder.Err())
+}
+
+func TestTransactionBuilder_BuildWithOutputTags(t *testing.T) {
+	identity := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identity.Public().(ed25519.PublicKey))
+	signer := iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &inputAddr, Keys: identity})
+	inputUTXO, _ := tpkg.RandUTXOInput()
+
+	outputAddr1, _ := tpkg.RandEd25519Address()
+	outputAddr2, _ := tpkg.RandEd25519Address()
+	outputAddr3, _ := tpkg.RandEd25519Address()
+	taggedOutput1 := &iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 3_000_000}
+	taggedOutput2 := &iotago.SigLockedSingleOutput{Address: outputAddr2, Amount: 1_000_000}
+	untaggedOutput := &iotago.SigLockedSingleOutput{Address: outputAddr3, Amount: 2_000_000}
+
+	tx, tags, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO}).
+		AddTaggedOutput(taggedOutput1, "invoice-1").
+		AddOutput(untaggedOutput).
+		AddTaggedOutput(taggedOutput2, "invoice-2").
+		BuildWithOutputTags(signer)
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+
+	outputIDs, err := tx.OutputIDs()
+	require.NoError(t, err)
+
+	outputs := tx.Essence.(*iotago.TransactionEssence).Outputs
+	for i, output := range outputs {
+		tag, has := tags[outputIDs[i]]
+		switch output {
+		case taggedOutput1:
+			require.Equal(t, "invoice-1", tag)
+		case taggedOutput2:
+			require.Equal(t, "invoice-2", tag)
+		default:
+			require.False(t, has)
+		}
+	}
+}
+
+func TestTransactionBuilder_WithMinOutputAmount(t *testing.T) {
+	inputAddr, _ := tpkg.RandEd25519Address()
+	dustAddr, _ := tpkg.RandEd25519Address()
+	otherAddr, _ := tpkg.RandEd25519Address()
+
+	type test struct {
+		name      string
+		threshold uint64
+		outputs   []iotago.Output
+		buildErr  error
+	}
+
+	tests := []test{
+		{
+			name:      "ok - outputs above threshold",
+			threshold: iotago.OutputSigLockedDustAllowanceOutputMinDeposit,
+			outputs: []iotago.Output{
+				&iotago.SigLockedSingleOutput{Address: dustAddr, Amount: iotago.OutputSigLockedDustAllowanceOutputMinDeposit},
+			},
+		},
+		{
+			name:      "ok - check disabled",
+			threshold: 0,
+			outputs: []iotago.Output{
+				&iotago.SigLockedSingleOutput{Address: dustAddr, Amount: 500},
+			},
+		},
+		{
+			name:      "ok - dust output covered by dust allowance",
+			threshold: iotago.OutputSigLockedDustAllowanceOutputMinDeposit,
+			outputs: []iotago.Output{
+				&iotago.SigLockedSingleOutput{Address: dustAddr, Amount: 500},
+				&iotago.SigLockedDustAllowanceOutput{Address: dustAddr, Amount: iotago.OutputSigLockedDustAllowanceOutputMinDeposit},
+			},
+		},
+		{
+			name:      "err - dust output without dust allowance",
+			threshold: iotago.OutputSigLockedDustAllowanceOutputMinDeposit,
+			outputs: []iotago.Output{
+				&iotago.SigLockedSingleOutput{Address: dustAddr, Amount: 500},
+			},
+			buildErr: iotago.ErrTransactionBuilderDustViolation,
+		},
+		{
+			name:      "err - dust allowance on another address",
+			threshold: iotago.OutputSigLockedDustAllowanceOutputMinDeposit,
+			outputs: []iotago.Output{
+				&iotago.SigLockedSingleOutput{Address: dustAddr, Amount: 500},
+				&iotago.SigLockedDustAllowanceOutput{Address: otherAddr, Amount: iotago.OutputSigLockedDustAllowanceOutputMinDeposit},
+			},
+			buildErr: iotago.ErrTransactionBuilderDustViolation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inputUTXO, _ := tpkg.RandUTXOInput()
+			builder := iotago.NewTransactionBuilder().
+				AddInput(&iotago.ToBeSignedUTXOInput{Address: inputAddr, Input: inputUTXO}).
+				WithMinOutputAmount(tt.threshold)
+			for _, output := range tt.outputs {
+				builder.AddOutput(output)
+			}
+
+			_, _, err := builder.BuildEssence()
+			if tt.buildErr != nil {
+				assert.True(t, errors.Is(err, tt.buildErr))
+				assert.Contains(t, err.Error(), dustAddr.String())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTransactionBuilder_Preview(t *testing.T) {
+	inputAddr, _ := tpkg.RandEd25519Address()
+	recipient1, _ := tpkg.RandEd25519Address()
+	recipient2, _ := tpkg.RandEd25519Address()
+	changeAddr, _ := tpkg.RandEd25519Address()
+	inputUTXO1, _ := tpkg.RandUTXOInput()
+	inputUTXO2, _ := tpkg.RandUTXOInput()
+
+	builder := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: inputAddr, Input: inputUTXO1, Output: &iotago.SigLockedSingleOutput{Address: inputAddr, Amount: 2_000_000}}).
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: inputAddr, Input: inputUTXO2, Output: &iotago.SigLockedSingleOutput{Address: inputAddr, Amount: 1_337_000}}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: recipient1, Amount: 1_000_000}).
+		AddOutput(&iotago.SigLockedDustAllowanceOutput{Address: recipient2, Amount: 1_000_000}).
+		AddRemainderOutput(changeAddr)
+	before := builder.String()
+
+	preview, err := builder.Preview(iotago.PrefixTestnet)
+	require.NoError(t, err)
+	require.Equal(t, &iotago.TransactionPreview{
+		InputSum:  3_337_000,
+		OutputSum: 3_337_000,
+		Recipients: map[string]uint64{
+			recipient1.Bech32(iotago.PrefixTestnet): 1_000_000,
+			recipient2.Bech32(iotago.PrefixTestnet): 1_000_000,
+		},
+		Change:        1_337_000,
+		ChangeAddress: changeAddr.Bech32(iotago.PrefixTestnet),
+	}, preview)
+	require.Equal(t, before, builder.String())
+
+	// outputs exceeding the inputs
+	_, err = builder.Clone().
+		AddOutput(&iotago.SigLockedSingleOutput{Address: changeAddr, Amount: 5_000_000}).
+		Preview(iotago.PrefixTestnet)
+	require.True(t, errors.Is(err, iotago.ErrTransactionBuilderInsufficientInputs))
+
+	// unknown input value
+	unknownUTXO, _ := tpkg.RandUTXOInput()
+	_, err = builder.Clone().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: inputAddr, Input: unknownUTXO}).
+		Preview(iotago.PrefixTestnet)
+	require.True(t, errors.Is(err, iotago.ErrTransactionBuilder))
+}
+
+func TestTransactionBuilder_String(t *testing.T) {
+	inputAddr, _ := tpkg.RandEd25519Address()
+	outputAddr, _ := tpkg.RandEd25519Address()
+	inputUTXO1, _ := tpkg.RandUTXOInput()
+	inputUTXO2, _ := tpkg.RandUTXOInput()
+	inputID1, inputID2 := inputUTXO1.ID(), inputUTXO2.ID()
+
+	newBuilder := func(inputs ...*iotago.UTXOInput) *iotago.TransactionBuilder {
+		builder := iotago.NewTransactionBuilder()
+		for _, input := range inputs {
+			builder.AddInput(&iotago.ToBeSignedUTXOInput{Address: inputAddr, Input: input})
+		}
+		return builder.
+			AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 1337}).
+			AddIndexationPayload(&iotago.Indexation{Index: []byte("index")})
+	}
+
+	str := newBuilder(inputUTXO1, inputUTXO2).StringWithHRP(iotago.PrefixTestnet)
+	require.Equal(t, str, newBuilder(inputUTXO2, inputUTXO1).StringWithHRP(iotago.PrefixTestnet))
+	require.Contains(t, str, "inputs (2):")
+	require.Contains(t, str, fmt.Sprintf("%s address=%s", inputID1.ToHex(), inputAddr.Bech32(iotago.PrefixTestnet)))
+	require.Contains(t, str, fmt.Sprintf("%s address=%s", inputID2.ToHex(), inputAddr.Bech32(iotago.PrefixTestnet)))
+	require.Contains(t, str, "outputs (1):")
+	require.Contains(t, str, fmt.Sprintf("*iotago.SigLockedSingleOutput address=%s amount=1337", outputAddr.Bech32(iotago.PrefixTestnet)))
+	require.Contains(t, str, "payload: *iotago.Indexation")
+	require.Contains(t, str, "error: none")
+
+	errStr := newBuilder(inputUTXO1, inputUTXO1).String()
+	require.Contains(t, errStr, outputAddr.Bech32(iotago.PrefixMainnet))
+	require.Contains(t, errStr, iotago.ErrTransactionBuilderDuplicatedInput.Error())
+}
+
+func TestTransactionBuilder_AddOutputWithUnit(t *testing.T) {
+	addr, _ := tpkg.RandEd25519Address()
+
+	tests := []struct {
+		name   string
+		amount string
+		output *iotago.SigLockedSingleOutput
+		err    error
+	}{
+		{name: "ok - Mi", amount: "2 Mi", output: &iotago.SigLockedSingleOutput{Address: addr, Amount: 2_000_000}},
+		{name: "ok - fractional Gi", amount: "1.5 Gi", output: &iotago.SigLockedSingleOutput{Address: addr, Amount: 1_500_000_000}},
+		{name: "ok - total supply", amount: "2779530283277761 i", output: &iotago.SigLockedSingleOutput{Address: addr, Amount: iotago.TokenSupply}},
+		{name: "err - exceeds total supply", amount: "2.8 Pi", err: iotago.ErrTransactionBuilderExceedsTokenSupply},
+		{name: "err - overflow", amount: "20000 Pi", err: iotago.ErrTransactionBuilder},
+		{name: "err - zero", amount: "0 Mi", err: iotago.ErrTransactionBuilder},
+		{name: "err - invalid", amount: "two Mi", err: iotago.ErrTransactionBuilder},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inputUTXO, _ := tpkg.RandUTXOInput()
+			builder := iotago.NewTransactionBuilder().
+				WithInputsOutputsSortMode(iotago.TransactionBuilderSortModeNone).
+				AddInput(&iotago.ToBeSignedUTXOInput{Address: addr, Input: inputUTXO}).
+				AddOutputWithUnit(addr, tt.amount)
+			if tt.err != nil {
+				assert.True(t, errors.Is(builder.Err(), tt.err))
+				return
+			}
+			require.NoError(t, builder.Err())
+
+			essence, _, err := builder.BuildEssence()
+			require.NoError(t, err)
+			require.Len(t, essence.Outputs, 1)
+			require.EqualValues(t, tt.output, essence.Outputs[0])
+		})
+	}
+}
+
+func TestTransactionBuilder_OutputDeposits(t *testing.T) {
+	addr, _ := tpkg.RandEd25519Address()
+	inputUTXO, _ := tpkg.RandUTXOInput()
+
+	tests := []struct {
+		name    string
+		amounts []uint64
+		err     error
+	}{
+		{name: "ok - total supply", amounts: []uint64{iotago.TokenSupply - 1, 1}},
+		{name: "err - exceeds total supply", amounts: []uint64{iotago.TokenSupply, 1}, err: iotago.ErrTransactionBuilderExceedsTokenSupply},
+		{name: "err - uint64 overflow", amounts: []uint64{1, math.MaxUint64}, err: iotago.ErrTransactionBuilderExceedsTokenSupply},
+		{name: "err - zero deposit", amounts: []uint64{1337, 0}, err: iotago.ErrTransactionBuilderZeroDepositOutput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := iotago.NewTransactionBuilder().
+				WithInputsOutputsSortMode(iotago.TransactionBuilderSortModeNone).
+				AddInput(&iotago.ToBeSignedUTXOInput{Address: addr, Input: inputUTXO})
+			for _, amount := range tt.amounts {
+				builder.AddOutput(&iotago.SigLockedSingleOutput{Address: addr, Amount: amount})
+			}
+
+			_, _, err := builder.BuildEssence()
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err))
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestTransactionBuilder_IsEssenceSorted(t *testing.T) {
+	addr, _ := tpkg.RandEd25519Address()
+	inputs := serializer.Serializables{
+		&iotago.UTXOInput{TransactionID: [iotago.TransactionIDLength]byte{1}},
+		&iotago.UTXOInput{TransactionID: [iotago.TransactionIDLength]byte{2}},
+	}
+	outputs := serializer.Serializables{
+		&iotago.SigLockedSingleOutput{Address: addr, Amount: 1},
+		&iotago.SigLockedSingleOutput{Address: addr, Amount: 2},
+	}
+
+	newBuilder := func(inputs, outputs serializer.Serializables) *iotago.TransactionBuilder {
+		builder := iotago.NewTransactionBuilder()
+		for _, input := range inputs {
+			builder.AddInput(&iotago.ToBeSignedUTXOInput{Address: addr, Input: input.(*iotago.UTXOInput)})
+		}
+		for _, output := range outputs {
+			builder.AddOutput(output.(iotago.Output))
+		}
+		return builder
+	}
+
+	tests := []struct {
+		name    string
+		builder *iotago.TransactionBuilder
+		sorted  bool
+	}{
+		{name: "empty", builder: iotago.NewTransactionBuilder(), sorted: true},
+		{name: "sorted", builder: newBuilder(inputs, outputs), sorted: true},
+		{name: "unsorted inputs", builder: newBuilder(serializer.Serializables{inputs[1], inputs[0]}, outputs)},
+		{name: "unsorted outputs", builder: newBuilder(inputs, serializer.Serializables{outputs[1], outputs[0]})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted, err := tt.builder.IsEssenceSorted()
+			require.NoError(t, err)
+			require.Equal(t, tt.sorted, sorted)
+
+			// a second call yields the same result as the check does not reorder
+			sortedAgain, err := tt.builder.IsEssenceSorted()
+			require.NoError(t, err)
+			require.Equal(t, sorted, sortedAgain)
+		})
+	}
+}
+
+func TestNewTransactionBuilderFromTransaction(t *testing.T) {
+	identity := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identity.Public().(ed25519.PublicKey))
+	signer := iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &inputAddr, Keys: identity})
+	outputAddr, _ := tpkg.RandEd25519Address()
+	inputUTXO1, _ := tpkg.RandUTXOInput()
+	inputUTXO2, _ := tpkg.RandUTXOInput()
+	inputToAddr := map[iotago.UTXOInputID]iotago.Address{inputUTXO1.ID(): &inputAddr, inputUTXO2.ID(): &inputAddr}
+
+	tx, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO2}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 1337}).
+		AddIndexationPayload(&iotago.Indexation{Index: []byte("index")}).
+		Build(signer)
+	require.NoError(t, err)
+	txEssence := tx.Essence.(*iotago.TransactionEssence)
+	txUnlockBlocks := tx.UnlockBlocks
+
+	t.Run("ok - rebuild unmodified", func(t *testing.T) {
+		rebuiltTx, err := iotago.NewTransactionBuilderFromTransaction(tx, inputToAddr).Build(signer)
+		require.NoError(t, err)
+		require.EqualValues(t, tx, rebuiltTx)
+	})
+
+	t.Run("ok - modify and resign", func(t *testing.T) {
+		otherOutputAddr, _ := tpkg.RandEd25519Address()
+		modifiedTx, err := iotago.NewTransactionBuilderFromTransaction(tx, inputToAddr).
+			AddOutput(&iotago.SigLockedSingleOutput{Address: otherOutputAddr, Amount: 1_000_000}).
+			WithPostSignVerification().
+			Build(signer)
+		require.NoError(t, err)
+
+		modifiedEssence := modifiedTx.Essence.(*iotago.TransactionEssence)
+		require.Len(t, modifiedEssence.Inputs, 2)
+		require.Len(t, modifiedEssence.Outputs, 2)
+		require.Equal(t, txEssence.Payload, modifiedEssence.Payload)
+		require.NotEqual(t, txUnlockBlocks, modifiedTx.UnlockBlocks)
+
+		// the original transaction is untouched
+		require.Len(t, txEssence.Outputs, 1)
+		require.Equal(t, txUnlockBlocks, tx.UnlockBlocks)
+	})
+
+	t.Run("err - missing input address", func(t *testing.T) {
+		builder := iotago.NewTransactionBuilderFromTransaction(tx, map[iotago.UTXOInputID]iotago.Address{inputUTXO1.ID(): &inputAddr})
+		assert.True(t, errors.Is(builder.Err(), iotago.ErrTransactionBuilder))
+	})
+}
+
+func TestTransactionBuilder_InvalidEssencePayload(t *testing.T) {
+	identity := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identity.Public().(ed25519.PublicKey))
+	signer := iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &inputAddr, Keys: identity})
+	outputAddr, _ := tpkg.RandEd25519Address()
+	inputUTXO, _ := tpkg.RandUTXOInput()
+	inputToAddr := map[iotago.UTXOInputID]iotago.Address{inputUTXO.ID(): &inputAddr}
+
+	tx, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 1337}).
+		Build(signer)
+	require.NoError(t, err)
+
+	milestone, _ := tpkg.RandMilestone(nil)
+	for _, payload := range []serializer.Serializable{&iotago.Transaction{}, milestone} {
+		tx.Essence.(*iotago.TransactionEssence).Payload = payload
+		_, err = iotago.NewTransactionBuilderFromTransaction(tx, inputToAddr).Build(signer)
+		require.True(t, errors.Is(err, iotago.ErrTransactionBuilderInvalidEssencePayload))
+	}
+}
@@ -91,7 +91,20 @@ func (u *TransactionEssence) SortInputsOutputs() {
 	sort.Sort(serializer.SortedSerializables(u.Outputs))
 }
 
-// SigningMessage returns the to be signed message.
+// Hash returns the BLAKE2b-256 hash of the serialized TransactionEssence. Transactions which only differ in their
+// unlock blocks share the same essence hash, whereas their Transaction.ID, which covers the unlock blocks, differs.
+// As the signing message carries no domain separation, Hash equals SigningMessage, however Hash does not sort
+// the inputs and outputs and errors if they are not in lexical order.
+func (u *TransactionEssence) Hash() ([]byte, error) {
+	essenceBytes, err := u.Serialize(serializer.DeSeriModePerformValidation)
+	if err != nil {
+		return nil, fmt.Errorf("can't compute transaction essence hash: %w", err)
+	}
+	essenceBytesHash := blake2b.Sum256(essenceBytes)
+	return essenceBytesHash[:], nil
+}
+
+// SigningMessage returns the to be signed message, the hash of the TransactionEssence after sorting its inputs and outputs.
 func (u *TransactionEssence) SigningMessage() ([]byte, error) {
 	essenceBytes, err := u.Serialize(serializer.DeSeriModePerformValidation | serializer.DeSeriModePerformLexicalOrdering)
 	if err != nil {
@@ -101,6 +114,67 @@ func (u *TransactionEssence) SigningMessage() ([]byte, error) {
 	return essenceBytesHash[:], nil
 }
 
+// StructuredSigningMessage is a breakdown of the signing message of a TransactionEssence into hashes
+// of its components, i.e. for a hardware wallet to display what is being signed.
+type StructuredSigningMessage struct {
+	// The BLAKE2b-256 hash of the concatenated serialized inputs in lexical order.
+	InputsCommitment []byte
+	// The BLAKE2b-256 hash of the concatenated serialized outputs in lexical order.
+	OutputsCommitment []byte
+	// The BLAKE2b-256 hash of the serialized embedded payload, nil if there is none.
+	PayloadHash []byte
+	// The actual to be signed message as returned by SigningMessage.
+	SigningMessage []byte
+}
+
+// SigningMessageStructured returns the hashes of the inputs, outputs and payload of the TransactionEssence after
+// sorting its inputs and outputs, together with the actual signing message. The signing message is the hash over
+// the entire serialized TransactionEssence and is not derived from the component hashes, hence signatures must
+// still be produced over SigningMessage.
+func (u *TransactionEssence) SigningMessageStructured() (*StructuredSigningMessage, error) {
+	signingMessage, err := u.SigningMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	inputsCommitment, err := serializablesHash(u.Inputs)
+	if err != nil {
+		return nil, fmt.Errorf("can't compute inputs commitment: %w", err)
+	}
+
+	outputsCommitment, err := serializablesHash(u.Outputs)
+	if err != nil {
+		return nil, fmt.Errorf("can't compute outputs commitment: %w", err)
+	}
+
+	var payloadHash []byte
+	if u.Payload != nil {
+		if payloadHash, err = serializablesHash(serializer.Serializables{u.Payload}); err != nil {
+			return nil, fmt.Errorf("can't compute payload hash: %w", err)
+		}
+	}
+
+	return &StructuredSigningMessage{
+		InputsCommitment:  inputsCommitment,
+		OutputsCommitment: outputsCommitment,
+		PayloadHash:       payloadHash,
+		SigningMessage:    signingMessage,
+	}, nil
+}
+
+// returns the BLAKE2b-256 hash of the concatenation of the given serialized objects.
+func serializablesHash(seris serializer.Serializables) ([]byte, error) {
+	h, _ := blake2b.New256(nil)
+	for _, seri := range seris {
+		data, err := seri.Serialize(serializer.DeSeriModeNoValidation)
+		if err != nil {
+			return nil, err
+		}
+		_, _ = h.Write(data)
+	}
+	return h.Sum(nil), nil
+}
+
 func (u *TransactionEssence) Deserialize(data []byte, deSeriMode serializer.DeSerializationMode) (int, error) {
 	return serializer.NewDeserializer(data).
 		AbortIf(func(err error) error {
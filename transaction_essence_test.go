@@ -8,6 +8,7 @@ import (
 
 	"github.com/iotaledger/iota.go/v2"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
 )
 
 func TestTransactionEssenceSelector(t *testing.T) {
@@ -64,3 +65,60 @@ func TestTransactionEssence_Serialize(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionEssence_Hash(t *testing.T) {
+	tx, _ := tpkg.RandTransaction()
+	essence := tx.Essence.(*iotago.TransactionEssence)
+
+	hash, err := essence.Hash()
+	assert.NoError(t, err)
+	signingMsg, err := essence.SigningMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, signingMsg, hash)
+
+	// the unlock blocks change the transaction ID but not the essence hash
+	txID, err := tx.ID()
+	assert.NoError(t, err)
+	sigBlock, _ := tpkg.RandEd25519SignatureUnlockBlock()
+	otherTx := &iotago.Transaction{Essence: essence, UnlockBlocks: serializer.Serializables{sigBlock}}
+	otherTxID, err := otherTx.ID()
+	assert.NoError(t, err)
+	assert.NotEqual(t, txID, otherTxID)
+	otherHash, err := otherTx.Essence.(*iotago.TransactionEssence).Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, hash, otherHash)
+}
+
+func TestTransactionEssence_SigningMessageStructured(t *testing.T) {
+	tx, _ := tpkg.RandTransaction()
+	essence := tx.Essence.(*iotago.TransactionEssence)
+
+	commitment := func(seris serializer.Serializables) []byte {
+		h, _ := blake2b.New256(nil)
+		for _, seri := range seris {
+			data, err := seri.Serialize(serializer.DeSeriModeNoValidation)
+			assert.NoError(t, err)
+			h.Write(data)
+		}
+		return h.Sum(nil)
+	}
+
+	structured, err := essence.SigningMessageStructured()
+	assert.NoError(t, err)
+	signingMsg, err := essence.SigningMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, signingMsg, structured.SigningMessage)
+	assert.Equal(t, commitment(essence.Inputs), structured.InputsCommitment)
+	assert.Equal(t, commitment(essence.Outputs), structured.OutputsCommitment)
+	assert.Nil(t, structured.PayloadHash)
+
+	// the embedded payload only changes the payload hash and the signing message
+	indexation := &iotago.Indexation{Index: []byte("index"), Data: []byte("data")}
+	essence.Payload = indexation
+	withPayload, err := essence.SigningMessageStructured()
+	assert.NoError(t, err)
+	assert.Equal(t, structured.InputsCommitment, withPayload.InputsCommitment)
+	assert.Equal(t, structured.OutputsCommitment, withPayload.OutputsCommitment)
+	assert.Equal(t, commitment(serializer.Serializables{indexation}), withPayload.PayloadHash)
+	assert.NotEqual(t, structured.SigningMessage, withPayload.SigningMessage)
+}
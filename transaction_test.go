@@ -1,7 +1,9 @@
 package iotago_test
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/iotaledger/hive.go/serializer"
 	"github.com/iotaledger/iota.go/v2/tpkg"
 	"testing"
@@ -9,6 +11,7 @@ import (
 	"github.com/iotaledger/iota.go/v2"
 	"github.com/iotaledger/iota.go/v2/ed25519"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTransaction_Deserialize(t *testing.T) {
@@ -23,6 +26,22 @@ func TestTransaction_Deserialize(t *testing.T) {
 			txPayload, txPayloadData := tpkg.RandTransaction()
 			return test{"ok", txPayloadData, txPayload, nil}
 		}(),
+		func() test {
+			txPayload, txPayloadData := randTransactionWithRefUnlockBlock(2, 0)
+			return test{"ok - reference unlock block", txPayloadData, txPayload, nil}
+		}(),
+		func() test {
+			txPayload, txPayloadData := randTransactionWithRefUnlockBlock(2, 2)
+			return test{"err - self referencing unlock block", txPayloadData, txPayload, iotago.ErrRefUnlockBlockSelfRef}
+		}(),
+		func() test {
+			txPayload, txPayloadData := randTransactionWithRefUnlockBlock(1, 2)
+			return test{"err - forward referencing unlock block", txPayloadData, txPayload, iotago.ErrRefUnlockBlockForwardRef}
+		}(),
+		func() test {
+			txPayload, txPayloadData := randTransactionWithRefUnlockBlock(1, 0, 1)
+			return test{"err - reference to reference unlock block", txPayloadData, txPayload, iotago.ErrRefUnlockBlockRefToRef}
+		}(),
 	}
 
 	for _, tt := range tests {
@@ -40,6 +59,21 @@ func TestTransaction_Deserialize(t *testing.T) {
 	}
 }
 
+// returns a random transaction with at least three inputs in which the unlock blocks starting at the given index
+// are replaced by ReferenceUnlockBlocks with the given references.
+func randTransactionWithRefUnlockBlock(index int, refs ...uint16) (*iotago.Transaction, []byte) {
+	txPayload, _ := tpkg.RandTransaction()
+	for len(txPayload.UnlockBlocks) < 3 {
+		txPayload, _ = tpkg.RandTransaction()
+	}
+	for i, ref := range refs {
+		txPayload.UnlockBlocks[index+i] = &iotago.ReferenceUnlockBlock{Reference: ref}
+	}
+	txPayloadData, err := txPayload.Serialize(serializer.DeSeriModeNoValidation)
+	tpkg.Must(err)
+	return txPayload, txPayloadData
+}
+
 func TestTransaction_Serialize(t *testing.T) {
 	type test struct {
 		name   string
@@ -61,6 +95,113 @@ func TestTransaction_Serialize(t *testing.T) {
 	}
 }
 
+func TestTransaction_JSONRoundtrip(t *testing.T) {
+	identity := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identity.Public().(ed25519.PublicKey))
+	outputAddr, _ := tpkg.RandEd25519Address()
+	inputUTXO1, _ := tpkg.RandUTXOInput()
+	inputUTXO2, _ := tpkg.RandUTXOInput()
+
+	tx, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO2}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 1337}).
+		AddOutput(&iotago.SigLockedDustAllowanceOutput{Address: outputAddr, Amount: iotago.OutputSigLockedDustAllowanceOutputMinDeposit}).
+		AddIndexationPayload(&iotago.Indexation{Index: []byte("index"), Data: []byte("data")}).
+		Build(iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &inputAddr, Keys: identity}))
+	require.NoError(t, err)
+
+	txJson, err := json.Marshal(tx)
+	require.NoError(t, err)
+
+	// type tags are the integer type IDs used by the node REST API
+	jTx := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(txJson, &jTx))
+	require.EqualValues(t, iotago.TransactionPayloadTypeID, jTx["type"])
+	jUnlockBlocks := jTx["unlockBlocks"].([]interface{})
+	require.EqualValues(t, iotago.UnlockBlockSignature, jUnlockBlocks[0].(map[string]interface{})["type"])
+	require.EqualValues(t, iotago.UnlockBlockReference, jUnlockBlocks[1].(map[string]interface{})["type"])
+
+	tx2 := &iotago.Transaction{}
+	require.NoError(t, json.Unmarshal(txJson, tx2))
+	require.EqualValues(t, tx, tx2)
+
+	txData, err := tx.Serialize(serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+	txData2, err := tx2.Serialize(serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+	require.Equal(t, txData, txData2)
+}
+
+func TestTransaction_OutputIDs(t *testing.T) {
+	tx, _ := tpkg.RandTransaction()
+	txID, err := tx.ID()
+	require.NoError(t, err)
+
+	outputIDs, err := tx.OutputIDs()
+	require.NoError(t, err)
+	require.Len(t, outputIDs, len(tx.Essence.(*iotago.TransactionEssence).Outputs))
+	for i, outputID := range outputIDs {
+		require.Equal(t, *txID, outputID.TransactionID())
+		require.EqualValues(t, i, outputID.Index())
+	}
+
+	_, err = (&iotago.Transaction{}).OutputIDs()
+	require.True(t, errors.Is(err, iotago.ErrInvalidTransactionEssence))
+}
+
+func TestTransaction_SerializeWithOffsets(t *testing.T) {
+	tx, _ := tpkg.RandTransaction()
+	txEssence := tx.Essence.(*iotago.TransactionEssence)
+	txEssence.Payload = &iotago.Indexation{Index: []byte("index"), Data: []byte("data")}
+
+	data, offsets, err := tx.SerializeWithOffsets(serializer.DeSeriModeNoValidation)
+	require.NoError(t, err)
+	txData, err := tx.Serialize(serializer.DeSeriModeNoValidation)
+	require.NoError(t, err)
+	require.Equal(t, txData, data)
+
+	requireBytes := func(name string, seri serializer.Serializable) {
+		expected, err := seri.Serialize(serializer.DeSeriModeNoValidation)
+		require.NoError(t, err)
+		byteRange, has := offsets[name]
+		require.True(t, has, name)
+		require.Equal(t, expected, data[byteRange.Start:byteRange.End], name)
+	}
+
+	require.Equal(t, iotago.ByteRange{Start: 0, End: 4}, offsets["payloadType"])
+	require.Equal(t, iotago.ByteRange{Start: 4, End: 5}, offsets["essence.type"])
+	requireBytes("essence", txEssence)
+	requireBytes("essence.payload", txEssence.Payload)
+	for i, input := range txEssence.Inputs {
+		requireBytes(fmt.Sprintf("essence.inputs[%d]", i), input)
+	}
+	for i, output := range txEssence.Outputs {
+		requireBytes(fmt.Sprintf("essence.outputs[%d]", i), output)
+	}
+	for i, unlockBlock := range tx.UnlockBlocks {
+		requireBytes(fmt.Sprintf("unlockBlocks[%d]", i), unlockBlock)
+	}
+	require.Equal(t, offsets["essence"].End, offsets["unlockBlocks"].Start)
+	require.Equal(t, len(data), offsets["unlockBlocks"].End)
+
+	_, _, err = (&iotago.Transaction{}).SerializeWithOffsets(serializer.DeSeriModeNoValidation)
+	require.True(t, errors.Is(err, iotago.ErrInvalidTransactionEssence))
+}
+
+func TestTransaction_CBOR(t *testing.T) {
+	tx, _ := tpkg.RandTransaction()
+
+	cborData, err := tx.MarshalCBOR()
+	require.NoError(t, err)
+
+	desTx := &iotago.Transaction{}
+	require.NoError(t, desTx.UnmarshalCBOR(cborData))
+	require.EqualValues(t, tx, desTx)
+
+	require.True(t, errors.Is(desTx.UnmarshalCBOR([]byte{0xff}), iotago.ErrInvalidCBOR))
+}
+
 func TestTransaction_SemanticallyValidate(t *testing.T) {
 	identityOne := tpkg.RandEd25519PrivateKey()
 	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
@@ -161,6 +302,26 @@ func TestTransaction_SemanticallyValidate(t *testing.T) {
 				},
 			}
 		}(),
+		func() test {
+
+			outputAddr1, _ := tpkg.RandEd25519Address()
+			utxoAddr, _ := tpkg.RandEd25519Address()
+			inputUTXO1 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+
+			builder := iotago.NewTransactionBuilder().
+				AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+				AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 50})
+
+			return test{
+				name:       "err - signature does not unlock the address of the UTXO",
+				addrSigner: iotago.NewInMemoryAddressSigner(addrKeys),
+				builder:    builder,
+				validErr:   iotago.ErrEd25519PubKeyAndAddrMismatch,
+				inputUTXOs: iotago.InputToOutputMapping{
+					inputUTXO1.ID(): &iotago.SigLockedSingleOutput{Address: utxoAddr, Amount: 50},
+				},
+			}
+		}(),
 	}
 
 	for _, test := range tests {
@@ -14,7 +14,7 @@ const (
 // TreasuryOutput is an output which holds the treasury of a network.
 type TreasuryOutput struct {
 	// The currently residing funds in the treasury.
-	Amount uint64 `json:"deposit"`
+	Amount uint64 `json:"amount"`
 }
 
 func (t *TreasuryOutput) Deserialize(data []byte, deSeriMode serializer.DeSerializationMode) (int, error) {
@@ -2,8 +2,19 @@
 package units
 
 import (
+	"errors"
+	"fmt"
 	"math"
 	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	// ErrInvalidAmount gets returned when an amount string can not be parsed.
+	ErrInvalidAmount = errors.New("invalid amount")
+	// ErrAmountOverflow gets returned when a parsed amount does not fit into an uint64 of iotas.
+	ErrAmountOverflow = errors.New("amount overflows uint64")
 )
 
 // Unit a unit of IOTAs.
@@ -45,3 +56,102 @@ func ConvertUnitsString(val string, from Unit, to Unit) (float64, error) {
 	}
 	return ConvertUnits(floatValue, from, to), nil
 }
+
+// units ordered ascending with their symbol and amount of decimals in I.
+var unitDefs = []struct {
+	unit     Unit
+	symbol   string
+	decimals int
+}{
+	{I, "i", 0},
+	{Ki, "Ki", 3},
+	{Mi, "Mi", 6},
+	{Gi, "Gi", 9},
+	{Ti, "Ti", 12},
+	{Pi, "Pi", 15},
+}
+
+// String returns the symbol of the Unit, e.g. "Mi".
+func (u Unit) String() string {
+	for _, def := range unitDefs {
+		if def.unit == u {
+			return def.symbol
+		}
+	}
+	return fmt.Sprintf("Unit(%g)", float64(u))
+}
+
+// ParseAmount parses the given amount string, a decimal number optionally followed by a Unit symbol such as "1.5 Gi",
+// into its amount of iotas. Amounts without a Unit symbol are interpreted as iotas. Unlike ConvertUnitsString,
+// the conversion is exact and amounts which are more precise than a single iota are rejected.
+func ParseAmount(s string) (uint64, error) {
+	s = strings.TrimSpace(s)
+	numStr, symbol := s, "i"
+	if idx := strings.IndexFunc(s, unicode.IsLetter); idx != -1 {
+		numStr, symbol = strings.TrimSpace(s[:idx]), s[idx:]
+	}
+
+	decimals := -1
+	for _, def := range unitDefs {
+		if def.symbol == symbol {
+			decimals = def.decimals
+			break
+		}
+	}
+	if decimals == -1 {
+		return 0, fmt.Errorf("%w: unknown unit %q", ErrInvalidAmount, symbol)
+	}
+
+	intPart, fracPart := numStr, ""
+	if idx := strings.IndexByte(numStr, '.'); idx != -1 {
+		intPart, fracPart = numStr[:idx], numStr[idx+1:]
+	}
+	if !isDigits(intPart) || (fracPart != "" && !isDigits(fracPart)) {
+		return 0, fmt.Errorf("%w: %q is not a decimal number", ErrInvalidAmount, numStr)
+	}
+	if len(fracPart) > decimals {
+		return 0, fmt.Errorf("%w: %q is more precise than 1i", ErrInvalidAmount, s)
+	}
+
+	amount, err := strconv.ParseUint(intPart+fracPart+strings.Repeat("0", decimals-len(fracPart)), 10, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, fmt.Errorf("%w: %q", ErrAmountOverflow, s)
+		}
+		return 0, fmt.Errorf("%w: %s", ErrInvalidAmount, err)
+	}
+	return amount, nil
+}
+
+// FormatAmount formats the given amount of iotas in the given Unit without loss of precision, e.g. "1.5 Gi".
+func FormatAmount(amount uint64, unit Unit) string {
+	decimals := 0
+	for _, def := range unitDefs {
+		if def.unit == unit {
+			decimals = def.decimals
+			break
+		}
+	}
+
+	digits := strconv.FormatUint(amount, 10)
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	intPart, fracPart := digits[:len(digits)-decimals], strings.TrimRight(digits[len(digits)-decimals:], "0")
+	if fracPart == "" {
+		return fmt.Sprintf("%s %s", intPart, unit)
+	}
+	return fmt.Sprintf("%s.%s %s", intPart, fracPart, unit)
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -1,7 +1,9 @@
 package units_test
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/iotaledger/iota.go/v2/units"
@@ -65,3 +67,62 @@ func ExampleConvertUnitsString() {
 	fmt.Println(conv)
 	// Output: 1.01e+10
 }
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		expected uint64
+		err      error
+	}{
+		{name: "iotas without unit", in: "1337", expected: 1337},
+		{name: "iotas", in: "1337 i", expected: 1337},
+		{name: "Mi", in: "2 Mi", expected: 2_000_000},
+		{name: "fractional Gi", in: "1.5 Gi", expected: 1_500_000_000},
+		{name: "no space", in: "1.5Gi", expected: 1_500_000_000},
+		{name: "smallest fraction", in: "0.000001 Mi", expected: 1},
+		{name: "max uint64", in: "18446744073709551615", expected: math.MaxUint64},
+		{name: "err - overflow", in: "18446.744073709551616 Pi", err: units.ErrAmountOverflow},
+		{name: "err - more precise than an iota", in: "0.0000001 Mi", err: units.ErrInvalidAmount},
+		{name: "err - unknown unit", in: "1 Xi", err: units.ErrInvalidAmount},
+		{name: "err - negative", in: "-1 Mi", err: units.ErrInvalidAmount},
+		{name: "err - no number", in: "Mi", err: units.ErrInvalidAmount},
+		{name: "err - no integer part", in: ".5 Mi", err: units.ErrInvalidAmount},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			amount, err := units.ParseAmount(test.in)
+			if test.err != nil {
+				assert.True(t, errors.Is(err, test.err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, amount)
+		})
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       uint64
+		unit     units.Unit
+		expected string
+	}{
+		{name: "iotas", in: 1337, unit: units.I, expected: "1337 i"},
+		{name: "whole Mi", in: 2_000_000, unit: units.Mi, expected: "2 Mi"},
+		{name: "fractional Gi", in: 1_500_000_000, unit: units.Gi, expected: "1.5 Gi"},
+		{name: "less than one", in: 1, unit: units.Mi, expected: "0.000001 Mi"},
+		{name: "zero", in: 0, unit: units.Ti, expected: "0 Ti"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			formatted := units.FormatAmount(test.in, test.unit)
+			assert.Equal(t, test.expected, formatted)
+
+			parsed, err := units.ParseAmount(formatted)
+			assert.NoError(t, err)
+			assert.Equal(t, test.in, parsed)
+		})
+	}
+}
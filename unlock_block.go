@@ -28,6 +28,12 @@ var (
 	ErrSigUnlockBlocksNotUnique = errors.New("signature unlock blocks must be unique")
 	// ErrRefUnlockBlockInvalidRef gets returned if a reference unlock block does not reference a signature unlock block.
 	ErrRefUnlockBlockInvalidRef = errors.New("reference unlock block must point to a previous signature unlock block")
+	// ErrRefUnlockBlockSelfRef gets returned if a reference unlock block references itself.
+	ErrRefUnlockBlockSelfRef = fmt.Errorf("%w: references itself", ErrRefUnlockBlockInvalidRef)
+	// ErrRefUnlockBlockForwardRef gets returned if a reference unlock block references an unlock block after it.
+	ErrRefUnlockBlockForwardRef = fmt.Errorf("%w: references a subsequent unlock block", ErrRefUnlockBlockInvalidRef)
+	// ErrRefUnlockBlockRefToRef gets returned if a reference unlock block references another reference unlock block.
+	ErrRefUnlockBlockRefToRef = fmt.Errorf("%w: references another reference unlock block", ErrRefUnlockBlockInvalidRef)
 	// ErrSigUnlockBlockHasNilSig gets returned if a signature unlock block contains a nil signature.
 	ErrSigUnlockBlockHasNilSig = errors.New("signature is nil")
 )
@@ -161,9 +167,11 @@ type UnlockBlockValidatorFunc func(index int, unlockBlock serializer.Serializabl
 
 // UnlockBlocksSigUniqueAndRefValidator returns a validator which checks that:
 //	1. signature unlock blocks are unique
-//	2. reference unlock blocks reference a previous signature unlock block
+//	2. reference unlock blocks reference a previous signature unlock block, returning an error wrapping
+//	   ErrRefUnlockBlockSelfRef, ErrRefUnlockBlockForwardRef or ErrRefUnlockBlockRefToRef otherwise
 func UnlockBlocksSigUniqueAndRefValidator() UnlockBlockValidatorFunc {
 	seenSigBlocks := map[int]struct{}{}
+	seenRefBlocks := map[int]struct{}{}
 	seenSigBlocksBytes := map[string]int{}
 
 	return func(index int, unlockBlock serializer.Serializable) error {
@@ -191,9 +199,19 @@ func UnlockBlocksSigUniqueAndRefValidator() UnlockBlockValidatorFunc {
 			}
 		case *ReferenceUnlockBlock:
 			reference := int(x.Reference)
+			_, isRefBlock := seenRefBlocks[reference]
+			switch {
+			case reference == index:
+				return fmt.Errorf("%w: unlock block at index %d", ErrRefUnlockBlockSelfRef, index)
+			case reference > index:
+				return fmt.Errorf("%w: %d references unlock block %d", ErrRefUnlockBlockForwardRef, index, reference)
+			case isRefBlock:
+				return fmt.Errorf("%w: %d references unlock block %d", ErrRefUnlockBlockRefToRef, index, reference)
+			}
 			if _, has := seenSigBlocks[reference]; !has {
 				return fmt.Errorf("%w: %d references non existent unlock block %d", ErrRefUnlockBlockInvalidRef, index, reference)
 			}
+			seenRefBlocks[index] = struct{}{}
 		default:
 			return fmt.Errorf("%w: unlock block at index %d is of unknown type %T", ErrUnknownUnlockBlockType, index, x)
 		}
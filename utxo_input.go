@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/iotaledger/hive.go/serializer"
 )
@@ -18,15 +19,55 @@ const (
 	UTXOInputSize = serializer.SmallTypeDenotationByteSize + TransactionIDLength + serializer.UInt16ByteSize
 )
 
+var (
+	// ErrUTXOInputIDInvalidLength gets returned when a UTXOInputID is parsed from data of the wrong length.
+	ErrUTXOInputIDInvalidLength = errors.New("invalid UTXO input ID length")
+)
+
 // UTXOInputID defines the identifier for an UTXO input which consists
 // out of the referenced transaction ID and the given output index.
 type UTXOInputID [TransactionIDLength + serializer.UInt16ByteSize]byte
 
+// ParseUTXOInputID parses the given hex string, the concatenation of the transaction ID and
+// the little endian encoded output index as used by the node's REST API, into a UTXOInputID.
+func ParseUTXOInputID(s string) (UTXOInputID, error) {
+	var utxoInputID UTXOInputID
+	utxoInputIDBytes, err := hex.DecodeString(s)
+	if err != nil {
+		return utxoInputID, fmt.Errorf("unable to decode UTXO input ID: %w", err)
+	}
+	if len(utxoInputIDBytes) != len(utxoInputID) {
+		return utxoInputID, fmt.Errorf("%w: expected %d bytes but got %d", ErrUTXOInputIDInvalidLength, len(utxoInputID), len(utxoInputIDBytes))
+	}
+	copy(utxoInputID[:], utxoInputIDBytes)
+	if index := utxoInputID.Index(); index < RefUTXOIndexMin || index > RefUTXOIndexMax {
+		return utxoInputID, fmt.Errorf("%w: got %d", ErrRefUTXOIndexInvalid, index)
+	}
+	return utxoInputID, nil
+}
+
 // ToHex converts the UTXOInputID to its hex representation.
 func (utxoInputID UTXOInputID) ToHex() string {
 	return fmt.Sprintf("%x", utxoInputID)
 }
 
+// TransactionID returns the transaction ID part of the UTXOInputID.
+func (utxoInputID UTXOInputID) TransactionID() TransactionID {
+	var txID TransactionID
+	copy(txID[:], utxoInputID[:TransactionIDLength])
+	return txID
+}
+
+// Index returns the output index part of the UTXOInputID.
+func (utxoInputID UTXOInputID) Index() uint16 {
+	return binary.LittleEndian.Uint16(utxoInputID[TransactionIDLength:])
+}
+
+// UTXOInput converts the UTXOInputID to the UTXOInput it identifies.
+func (utxoInputID UTXOInputID) UTXOInput() *UTXOInput {
+	return &UTXOInput{TransactionID: utxoInputID.TransactionID(), TransactionOutputIndex: utxoInputID.Index()}
+}
+
 // UTXOInputIDs is a slice of UTXOInputID.
 type UTXOInputIDs []UTXOInputID
 
@@ -1,6 +1,7 @@
 package iotago_test
 
 import (
+	"encoding/hex"
 	"errors"
 	"github.com/iotaledger/hive.go/serializer"
 	"github.com/iotaledger/iota.go/v2/tpkg"
@@ -56,3 +57,36 @@ func TestUTXOInput_Serialize(t *testing.T) {
 		})
 	}
 }
+
+func TestParseUTXOInputID(t *testing.T) {
+	randUTXOInput, _ := tpkg.RandUTXOInput()
+	randUTXOInputID := randUTXOInput.ID()
+
+	outOfBoundsUTXOInputID := (&iotago.UTXOInput{TransactionOutputIndex: iotago.RefUTXOIndexMax + 1}).ID()
+
+	tests := []struct {
+		name   string
+		source string
+		target iotago.UTXOInputID
+		err    error
+	}{
+		{"ok", randUTXOInputID.ToHex(), randUTXOInputID, nil},
+		{"not hex", "zz", iotago.UTXOInputID{}, hex.InvalidByteError('z')},
+		{"too short", randUTXOInputID.ToHex()[:64], iotago.UTXOInputID{}, iotago.ErrUTXOInputIDInvalidLength},
+		{"too long", randUTXOInputID.ToHex() + "00", iotago.UTXOInputID{}, iotago.ErrUTXOInputIDInvalidLength},
+		{"index out of bounds", outOfBoundsUTXOInputID.ToHex(), iotago.UTXOInputID{}, iotago.ErrRefUTXOIndexInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			utxoInputID, err := iotago.ParseUTXOInputID(tt.source)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.target, utxoInputID)
+			assert.Equal(t, tt.source, utxoInputID.ToHex())
+			assert.EqualValues(t, randUTXOInput, utxoInputID.UTXOInput())
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"math/rand"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/iotaledger/hive.go/serializer"
@@ -62,12 +63,13 @@ func NewNodeEventAPIClient(brokerURI string) *NodeEventAPIClient {
 	clientOpts.Order = false
 	clientOpts.ClientID = randMQTTClientID()
 	clientOpts.AddBroker(brokerURI)
+	clientOpts.AutoReconnect = true
 	errChan := make(chan error)
+	neac := &NodeEventAPIClient{Errors: errChan}
 	clientOpts.OnConnectionLost = func(client mqtt.Client, err error) { sendErrOrDrop(errChan, err) }
-	return &NodeEventAPIClient{
-		MQTTClient: mqtt.NewClient(clientOpts),
-		Errors:     errChan,
-	}
+	clientOpts.OnConnect = func(client mqtt.Client) { neac.resubscribe() }
+	neac.MQTTClient = mqtt.NewClient(clientOpts)
+	return neac
 }
 
 // NodeEventAPIClient represents a handle to retrieve channels for node events.
@@ -82,6 +84,10 @@ type NodeEventAPIClient struct {
 	// if the client was created without NewNodeEventAPIClient.
 	// Errors are dropped silently if no receiver is listening for them or can consume them fast enough.
 	Errors chan error
+
+	subsMu sync.Mutex
+	// the active subscriptions by their topic.
+	subs map[string]mqtt.MessageHandler
 }
 
 func panicIfNodeEventAPIClientInactive(neac *NodeEventAPIClient) {
@@ -116,11 +122,48 @@ func (neac *NodeEventAPIClient) Close() {
 	neac.MQTTClient.Disconnect(0)
 }
 
+// Unsubscribe cancels the subscriptions to the given topics, which are the NodeEvent* topics with their
+// placeholders filled in, i.e. NodeEventMessages or "messages/{messageId}/metadata" with the hex encoded message ID.
+// Channels of cancelled subscriptions are not closed but simply don't receive any further values.
+func (neac *NodeEventAPIClient) Unsubscribe(topics ...string) error {
+	neac.subsMu.Lock()
+	for _, topic := range topics {
+		delete(neac.subs, topic)
+	}
+	neac.subsMu.Unlock()
+
+	if token := neac.MQTTClient.Unsubscribe(topics...); token.Wait() && token.Error() != nil {
+		return token.Error()
+	}
+	return nil
+}
+
+// subscribes to the given topic and remembers the subscription in order to restore it on reconnects.
+func (neac *NodeEventAPIClient) subscribe(topic string, handler mqtt.MessageHandler) {
+	neac.subsMu.Lock()
+	if neac.subs == nil {
+		neac.subs = make(map[string]mqtt.MessageHandler)
+	}
+	neac.subs[topic] = handler
+	neac.subsMu.Unlock()
+
+	neac.MQTTClient.Subscribe(topic, 2, handler)
+}
+
+// restores the active subscriptions, as the broker drops them when the connection is lost.
+func (neac *NodeEventAPIClient) resubscribe() {
+	neac.subsMu.Lock()
+	defer neac.subsMu.Unlock()
+	for topic, handler := range neac.subs {
+		neac.MQTTClient.Subscribe(topic, 2, handler)
+	}
+}
+
 // Messages returns a channel of newly received messages.
 func (neac *NodeEventAPIClient) Messages() <-chan *iotago.Message {
 	panicIfNodeEventAPIClientInactive(neac)
 	channel := make(chan *iotago.Message)
-	neac.MQTTClient.Subscribe(NodeEventMessages, 2, func(client mqtt.Client, mqttMsg mqtt.Message) {
+	neac.subscribe(NodeEventMessages, func(client mqtt.Client, mqttMsg mqtt.Message) {
 		msg := &iotago.Message{}
 		if _, err := msg.Deserialize(mqttMsg.Payload(), serializer.DeSeriModePerformValidation); err != nil {
 			sendErrOrDrop(neac.Errors, err)
@@ -139,7 +182,7 @@ func (neac *NodeEventAPIClient) Messages() <-chan *iotago.Message {
 func (neac *NodeEventAPIClient) ReferencedMessagesMetadata() <-chan *iotago.MessageMetadataResponse {
 	panicIfNodeEventAPIClientInactive(neac)
 	channel := make(chan *iotago.MessageMetadataResponse)
-	neac.MQTTClient.Subscribe(NodeEventMessagesReferenced, 2, func(client mqtt.Client, mqttMsg mqtt.Message) {
+	neac.subscribe(NodeEventMessagesReferenced, func(client mqtt.Client, mqttMsg mqtt.Message) {
 		metadataRes := &iotago.MessageMetadataResponse{}
 		if err := json.Unmarshal(mqttMsg.Payload(), metadataRes); err != nil {
 			sendErrOrDrop(neac.Errors, err)
@@ -158,7 +201,7 @@ func (neac *NodeEventAPIClient) ReferencedMessagesMetadata() <-chan *iotago.Mess
 func (neac *NodeEventAPIClient) ReferencedMessages(nodeHTTPAPIClient *iotago.NodeHTTPAPIClient) <-chan *iotago.Message {
 	panicIfNodeEventAPIClientInactive(neac)
 	channel := make(chan *iotago.Message)
-	neac.MQTTClient.Subscribe(NodeEventMessagesReferenced, 2, func(client mqtt.Client, mqttMsg mqtt.Message) {
+	neac.subscribe(NodeEventMessagesReferenced, func(client mqtt.Client, mqttMsg mqtt.Message) {
 		metadataRes := &iotago.MessageMetadataResponse{}
 		if err := json.Unmarshal(mqttMsg.Payload(), metadataRes); err != nil {
 			sendErrOrDrop(neac.Errors, err)
@@ -183,7 +226,7 @@ func (neac *NodeEventAPIClient) ReferencedMessages(nodeHTTPAPIClient *iotago.Nod
 func (neac *NodeEventAPIClient) MessagesWithIndex(index string) <-chan *iotago.Message {
 	panicIfNodeEventAPIClientInactive(neac)
 	channel := make(chan *iotago.Message)
-	neac.MQTTClient.Subscribe(strings.Replace(NodeEventMessagesIndexation, "{index}", index, 1), 2, func(client mqtt.Client, mqttMsg mqtt.Message) {
+	neac.subscribe(strings.Replace(NodeEventMessagesIndexation, "{index}", index, 1), func(client mqtt.Client, mqttMsg mqtt.Message) {
 		msg := &iotago.Message{}
 		if _, err := msg.Deserialize(mqttMsg.Payload(), serializer.DeSeriModePerformValidation); err != nil {
 			sendErrOrDrop(neac.Errors, err)
@@ -203,7 +246,7 @@ func (neac *NodeEventAPIClient) MessageMetadataChange(msgID iotago.MessageID) <-
 	panicIfNodeEventAPIClientInactive(neac)
 	channel := make(chan *iotago.MessageMetadataResponse)
 	topic := strings.Replace(NodeEventMessagesMetadata, "{messageId}", iotago.MessageIDToHexString(msgID), 1)
-	neac.MQTTClient.Subscribe(topic, 2, func(client mqtt.Client, mqttMsg mqtt.Message) {
+	neac.subscribe(topic, func(client mqtt.Client, mqttMsg mqtt.Message) {
 		metadataRes := &iotago.MessageMetadataResponse{}
 		if err := json.Unmarshal(mqttMsg.Payload(), metadataRes); err != nil {
 			sendErrOrDrop(neac.Errors, err)
@@ -223,7 +266,7 @@ func (neac *NodeEventAPIClient) AddressOutputs(addr iotago.Address, netPrefix io
 	panicIfNodeEventAPIClientInactive(neac)
 	channel := make(chan *iotago.NodeOutputResponse)
 	topic := strings.Replace(NodeEventAddressesOutput, "{address}", addr.Bech32(netPrefix), 1)
-	neac.MQTTClient.Subscribe(topic, 2, func(client mqtt.Client, mqttMsg mqtt.Message) {
+	neac.subscribe(topic, func(client mqtt.Client, mqttMsg mqtt.Message) {
 		res := &iotago.NodeOutputResponse{}
 		if err := json.Unmarshal(mqttMsg.Payload(), res); err != nil {
 			sendErrOrDrop(neac.Errors, err)
@@ -243,7 +286,7 @@ func (neac *NodeEventAPIClient) Ed25519AddressOutputs(addr *iotago.Ed25519Addres
 	panicIfNodeEventAPIClientInactive(neac)
 	channel := make(chan *iotago.NodeOutputResponse)
 	topic := strings.Replace(NodeEventAddressesEd25519Output, "{address}", addr.String(), 1)
-	neac.MQTTClient.Subscribe(topic, 2, func(client mqtt.Client, mqttMsg mqtt.Message) {
+	neac.subscribe(topic, func(client mqtt.Client, mqttMsg mqtt.Message) {
 		res := &iotago.NodeOutputResponse{}
 		if err := json.Unmarshal(mqttMsg.Payload(), res); err != nil {
 			sendErrOrDrop(neac.Errors, err)
@@ -263,7 +306,7 @@ func (neac *NodeEventAPIClient) TransactionIncludedMessage(txID iotago.Transacti
 	panicIfNodeEventAPIClientInactive(neac)
 	channel := make(chan *iotago.Message)
 	topic := strings.Replace(NodeEventTransactionsIncludedMessage, "{transactionId}", iotago.MessageIDToHexString(txID), 1)
-	neac.MQTTClient.Subscribe(topic, 2, func(client mqtt.Client, mqttMsg mqtt.Message) {
+	neac.subscribe(topic, func(client mqtt.Client, mqttMsg mqtt.Message) {
 		msg := &iotago.Message{}
 		if _, err := msg.Deserialize(mqttMsg.Payload(), serializer.DeSeriModePerformValidation); err != nil {
 			sendErrOrDrop(neac.Errors, err)
@@ -283,7 +326,7 @@ func (neac *NodeEventAPIClient) Output(outputID iotago.UTXOInputID) <-chan *iota
 	panicIfNodeEventAPIClientInactive(neac)
 	channel := make(chan *iotago.NodeOutputResponse)
 	topic := strings.Replace(NodeEventOutputs, "{outputId}", hex.EncodeToString(outputID[:]), 1)
-	neac.MQTTClient.Subscribe(topic, 2, func(client mqtt.Client, mqttMsg mqtt.Message) {
+	neac.subscribe(topic, func(client mqtt.Client, mqttMsg mqtt.Message) {
 		res := &iotago.NodeOutputResponse{}
 		if err := json.Unmarshal(mqttMsg.Payload(), res); err != nil {
 			sendErrOrDrop(neac.Errors, err)
@@ -302,7 +345,7 @@ func (neac *NodeEventAPIClient) Output(outputID iotago.UTXOInputID) <-chan *iota
 func (neac *NodeEventAPIClient) Receipts() <-chan *iotago.Receipt {
 	panicIfNodeEventAPIClientInactive(neac)
 	channel := make(chan *iotago.Receipt)
-	neac.MQTTClient.Subscribe(NodeEventReceipts, 2, func(client mqtt.Client, mqttMsg mqtt.Message) {
+	neac.subscribe(NodeEventReceipts, func(client mqtt.Client, mqttMsg mqtt.Message) {
 		receipt := &iotago.Receipt{}
 		if err := json.Unmarshal(mqttMsg.Payload(), receipt); err != nil {
 			sendErrOrDrop(neac.Errors, err)
@@ -327,7 +370,7 @@ type MilestonePointer struct {
 func (neac *NodeEventAPIClient) LatestMilestones() <-chan *MilestonePointer {
 	panicIfNodeEventAPIClientInactive(neac)
 	channel := make(chan *MilestonePointer)
-	neac.MQTTClient.Subscribe(NodeEventMilestonesLatest, 2, func(client mqtt.Client, mqttMsg mqtt.Message) {
+	neac.subscribe(NodeEventMilestonesLatest, func(client mqtt.Client, mqttMsg mqtt.Message) {
 		msPointer := &MilestonePointer{}
 		if err := json.Unmarshal(mqttMsg.Payload(), msPointer); err != nil {
 			sendErrOrDrop(neac.Errors, err)
@@ -346,7 +389,7 @@ func (neac *NodeEventAPIClient) LatestMilestones() <-chan *MilestonePointer {
 func (neac *NodeEventAPIClient) LatestMilestoneMessages(nodeHTTPAPIClient *iotago.NodeHTTPAPIClient) <-chan *iotago.Message {
 	panicIfNodeEventAPIClientInactive(neac)
 	channel := make(chan *iotago.Message)
-	neac.MQTTClient.Subscribe(NodeEventMilestonesLatest, 2, func(client mqtt.Client, mqttMsg mqtt.Message) {
+	neac.subscribe(NodeEventMilestonesLatest, func(client mqtt.Client, mqttMsg mqtt.Message) {
 		msPointer := &MilestonePointer{}
 		if err := json.Unmarshal(mqttMsg.Payload(), msPointer); err != nil {
 			sendErrOrDrop(neac.Errors, err)
@@ -375,7 +418,7 @@ func (neac *NodeEventAPIClient) LatestMilestoneMessages(nodeHTTPAPIClient *iotag
 func (neac *NodeEventAPIClient) ConfirmedMilestones() <-chan *MilestonePointer {
 	panicIfNodeEventAPIClientInactive(neac)
 	channel := make(chan *MilestonePointer)
-	neac.MQTTClient.Subscribe(NodeEventMilestonesConfirmed, 2, func(client mqtt.Client, mqttMsg mqtt.Message) {
+	neac.subscribe(NodeEventMilestonesConfirmed, func(client mqtt.Client, mqttMsg mqtt.Message) {
 		msPointer := &MilestonePointer{}
 		if err := json.Unmarshal(mqttMsg.Payload(), msPointer); err != nil {
 			sendErrOrDrop(neac.Errors, err)
@@ -394,7 +437,7 @@ func (neac *NodeEventAPIClient) ConfirmedMilestones() <-chan *MilestonePointer {
 func (neac *NodeEventAPIClient) ConfirmedMilestoneMessages(nodeHTTPAPIClient *iotago.NodeHTTPAPIClient) <-chan *iotago.Message {
 	panicIfNodeEventAPIClientInactive(neac)
 	channel := make(chan *iotago.Message)
-	neac.MQTTClient.Subscribe(NodeEventMilestonesConfirmed, 2, func(client mqtt.Client, mqttMsg mqtt.Message) {
+	neac.subscribe(NodeEventMilestonesConfirmed, func(client mqtt.Client, mqttMsg mqtt.Message) {
 		msPointer := &MilestonePointer{}
 		if err := json.Unmarshal(mqttMsg.Payload(), msPointer); err != nil {
 			sendErrOrDrop(neac.Errors, err)
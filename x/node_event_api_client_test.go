@@ -38,9 +38,26 @@ func TestNewNodeEventAPIClient(t *testing.T) {
 	}, 5*time.Second, 100*time.Millisecond)
 }
 
+func TestNodeEventAPIClient_Unsubscribe(t *testing.T) {
+	_, originMsgBytes := tpkg.RandMessage(iotago.IndexationPayloadTypeID)
+	mock := &mockMqttClient{payload: originMsgBytes}
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	eventAPIClient := &iotagox.NodeEventAPIClient{
+		MQTTClient: mock,
+		Errors:     make(chan error),
+	}
+	require.NoError(t, eventAPIClient.Connect(ctx))
+
+	eventAPIClient.Messages()
+	require.NoError(t, eventAPIClient.Unsubscribe(iotagox.NodeEventMessages))
+	require.Equal(t, []string{iotagox.NodeEventMessages}, mock.unsubscribed)
+}
+
 type mockMqttClient struct {
-	payload []byte
-	f       func()
+	payload      []byte
+	f            func()
+	unsubscribed []string
 }
 
 type mockToken struct{}
@@ -96,7 +113,10 @@ func (m *mockMqttClient) SubscribeMultiple(filters map[string]byte, callback mqt
 	panic("implement me")
 }
 
-func (m *mockMqttClient) Unsubscribe(topics ...string) mqtt.Token { panic("implement me") }
+func (m *mockMqttClient) Unsubscribe(topics ...string) mqtt.Token {
+	m.unsubscribed = append(m.unsubscribed, topics...)
+	return &mockToken{}
+}
 
 func (m *mockMqttClient) AddRoute(topic string, callback mqtt.MessageHandler) { panic("implement me") }
 